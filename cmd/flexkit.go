@@ -2,14 +2,17 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 	"os"
+	"path"
 	"io/ioutil"
+	"strings"
 	"github.com/satnamram/flexkit/cmd/assets"
 )
 
 func usage() {
-	println("usage: flexkit <app.js> <address>")
+	println("usage: flexkit <app.js> <address> [prerendered.html]")
 	os.Exit(1)
 }
 
@@ -19,6 +22,22 @@ func main() {
 	}
 
 	assetFS := http.FileServer(assets.FS(false))
+
+	// the optional pre-rendered markup (see package ssr) replaces the spinner
+	var index string
+	if len(os.Args) > 3 {
+		b, err := ioutil.ReadFile(os.Args[3])
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+		index, err = prerender(string(b))
+		if err != nil {
+			println(err.Error())
+			os.Exit(1)
+		}
+	}
+
 	http.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.RequestURI == "/app.js" {
 			w.Header().Set("Content-Type", "application/javascript")
@@ -30,6 +49,16 @@ func main() {
 			w.Write(b)
 			return
 		}
+
+		// answer view paths (flexkit.RoutingPath) with the application page
+		if path.Ext(r.URL.Path) == "" {
+			r.URL.Path = "/"
+		}
+		if index != "" && (r.URL.Path == "/" || r.URL.Path == "/index.html") {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte(index))
+			return
+		}
 		assetFS.ServeHTTP(w, r)
 	}))
 
@@ -39,3 +68,24 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// spinner delimits the loading spinner of index.html and its stylesheet,
+// which the app removes as well when it renders into the body.
+const (
+	spinnerStart = `<link rel="stylesheet" href="index.css">`
+	spinnerEnd   = "<script>"
+)
+
+// prerender returns index.html with the spinner replaced by markup.
+func prerender(markup string) (string, error) {
+	index, err := assets.FSString(false, "/index.html")
+	if err != nil {
+		return "", err
+	}
+	start := strings.Index(index, spinnerStart)
+	end := strings.Index(index, spinnerEnd)
+	if start < 0 || end < start {
+		return "", errors.New("flexkit: spinner not found in index.html")
+	}
+	return index[:start] + markup + "\n" + index[end:], nil
+}
@@ -0,0 +1,5 @@
+// Package flexkit implements a programmatic user interface for single page applications.
+//
+// The app itself runs in the browser only, in non-js builds the flex and kit
+// packages render into the in-memory document of package dom for unit tests.
+package flexkit
@@ -0,0 +1,14 @@
+package dom
+
+// Adopted calls f once the elements created by the running Hydrate refer to
+// the nodes they adopted, or right away outside of Hydrate. Use it for
+// scripts which keep references to nodes or add listeners to them, e.g.
+// sorttable.js, since only the listeners added with Element.On move to the
+// adopted nodes.
+func Adopted(f func()) {
+	if hydrating != nil {
+		hydrating.adopted = append(hydrating.adopted, f)
+		return
+	}
+	f()
+}
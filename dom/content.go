@@ -0,0 +1,44 @@
+package dom
+
+import "strconv"
+
+func (element *Element) SetContent(v interface{}) *Element {
+	switch v := v.(type) {
+	case nil:
+		element.Set("innerHTML", "nil")
+	case string:
+		element.Set("innerHTML", v)
+	case int:
+		element.Set("innerHTML", strconv.Itoa(v))
+	default:
+		if e, ok := v.(Renderable); ok {
+			element.Set("innerHTML", "")
+			element.Append(e.Render())
+			break
+		}
+		panic("type unknown")
+	}
+	return element
+}
+
+// OnInput replaces the input listener of the element, nil removes it.
+func (element *Element) OnInput(f func()) {
+	element.onInput.Remove()
+	element.onInput = ListenerHandle{}
+	if f != nil {
+		element.onInput = element.On("input", func(*Event) {
+			f()
+		})
+	}
+}
+
+// OnClick replaces the click listener of the element, nil removes it.
+func (element *Element) OnClick(f func()) {
+	element.onClick.Remove()
+	element.onClick = ListenerHandle{}
+	if f != nil {
+		element.onClick = element.On("click", func(*Event) {
+			f()
+		})
+	}
+}
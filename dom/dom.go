@@ -1,9 +1,33 @@
+// +build !js
+
 package dom
 
-import "github.com/gopherjs/gopherjs/js"
+import (
+	"fmt"
+	"os"
+)
 
+// Outside of the browser the document is an in-memory node tree, which lets
+// widgets be rendered, inspected and driven by events in unit tests.
 var (
-	DOC  = js.Global.Get("document")
-	HEAD = getElement("head")
-	BODY = getElement("body")
+	DOC  = newDocument()
+	HEAD = DOC.Query("head")
+	BODY = DOC.Query("body")
 )
+
+func newDocument() *Element {
+	html := NewElement("html")
+	html.Append(NewElement("head"))
+	html.Append(NewElement("body"))
+	return html
+}
+
+// Reset removes everything rendered into the body of the document.
+func Reset() {
+	BODY.Set("innerHTML", "")
+}
+
+// logPanic writes a recovered panic to stderr.
+func logPanic(message string) {
+	fmt.Fprintln(os.Stderr, message)
+}
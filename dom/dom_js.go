@@ -0,0 +1,14 @@
+package dom
+
+import "github.com/gopherjs/gopherjs/js"
+
+var (
+	DOC  = js.Global.Get("document")
+	HEAD = getElement("head")
+	BODY = getElement("body")
+)
+
+// logPanic writes a recovered panic to the browser console.
+func logPanic(message string) {
+	js.Global.Get("console").Call("error", message)
+}
@@ -1,134 +1,420 @@
+// +build !js
+
 package dom
 
 import (
-	"github.com/gopherjs/gopherjs/js"
-	"strconv"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
 )
 
+// Element is a node of the in-memory document. Text nodes have the tag
+// "#text", markup assigned to innerHTML is kept unparsed in "#html" nodes.
 type Element struct {
-	Value   *js.Object
-	classes *js.Object
+	tag        string
+	text       string
+	attributes []attribute
+	classes    []string
+	properties map[string]interface{}
+	children   []*Element
+	parent     *Element
+	listeners  map[string][]*listener
+
+	onClick ListenerHandle
+	onInput ListenerHandle
+}
 
-	onClickCallback func()
-	onInputCallback func()
+type attribute struct {
+	name  string
+	value string
 }
 
 func NewElement(t string) *Element {
-	value := DOC.Call("createElement", t)
-	classes := value.Get("classList")
 	return &Element{
-		Value:   value,
-		classes: classes,
+		tag:        strings.ToLower(t),
+		properties: map[string]interface{}{},
+		listeners:  map[string][]*listener{},
 	}
 }
 
-func getElement(t string) *Element {
-	documentHead := DOC.Get(t)
-	documentHeadClasses := documentHead.Get("classList")
-	return &Element{
-		Value:   documentHead,
-		classes: documentHeadClasses,
-	}
+func newText(tag, text string) *Element {
+	e := NewElement(tag)
+	e.text = text
+	return e
+}
+
+// Query returns the first element of the document matching the CSS selector
+// or nil.
+func Query(selector string) *Element {
+	return DOC.Query(selector)
 }
 
+// Set a property, "innerHTML" and "textContent" replace the children and
+// reflected properties like "id" are set as attribute.
 func (element *Element) Set(p string, x interface{}) *Element {
-	if p == "type" {
-		js.Global.Call("makePropertyWriteable", *element.Value, "type")
+	switch p {
+	case "innerHTML", "textContent":
+		element.clear()
+		if s := fmt.Sprint(x); s != "" {
+			tag := "#html"
+			if p == "textContent" {
+				tag = "#text"
+			}
+			element.appendChild(newText(tag, s))
+		}
+	case "id", "name", "type", "href", "src", "title", "placeholder", "style":
+		element.SetAttribute(p, fmt.Sprint(x))
+	case "className":
+		element.SetAttribute("class", fmt.Sprint(x))
+	default:
+		element.properties[p] = x
 	}
-	element.Value.Set(p, x)
 	return element
 }
 
-func (element *Element) Get(p string) *js.Object {
-	return element.Value.Get(p)
+// GetString returns a property as string ("" if it is not set).
+func (element *Element) GetString(p string) string {
+	switch p {
+	case "innerHTML":
+		return element.InnerHTML()
+	case "textContent":
+		return element.Text()
+	case "id", "name", "type", "href", "src", "title", "placeholder", "style":
+		return element.Attribute(p)
+	case "className":
+		return strings.Join(element.classes, " ")
+	}
+	if v, ok := element.properties[p]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// Property returns a property set with Set (nil if it is not set).
+func (element *Element) Property(p string) interface{} {
+	return element.properties[p]
+}
+
+// SetStyle sets an inline style property ("resize", "none").
+func (element *Element) SetStyle(property string, value string) *Element {
+	declarations := []string{}
+	for _, declaration := range strings.Split(element.Attribute("style"), ";") {
+		name := strings.TrimSpace(strings.SplitN(declaration, ":", 2)[0])
+		if name != "" && name != property {
+			declarations = append(declarations, strings.TrimSpace(declaration))
+		}
+	}
+	declarations = append(declarations, property+": "+value)
+	return element.SetAttribute("style", strings.Join(declarations, "; ")+";")
 }
 
 func (element *Element) Prepend(e *Element) *Element {
-	element.Value.Call("prepend", e.Value)
+	e.detach()
+	e.parent = element
+	element.children = append([]*Element{e}, element.children...)
 	return element
 }
 
 func (element *Element) Append(e *Element) *Element {
-	element.Value.Call("append", *e.Value)
+	e.detach()
+	element.appendChild(e)
+	return element
+}
+
+func (element *Element) appendChild(e *Element) {
+	e.parent = element
+	element.children = append(element.children, e)
+}
+
+// insertBefore inserts e in front of the child reference.
+func (element *Element) insertBefore(e, reference *Element) {
+	e.detach()
+	for i, child := range element.children {
+		if child == reference {
+			e.parent = element
+			element.children = append(element.children[:i], append([]*Element{e}, element.children[i:]...)...)
+			return
+		}
+	}
+	element.appendChild(e)
+}
+
+// detach removes the element from its parent.
+func (element *Element) detach() {
+	if element.parent == nil {
+		return
+	}
+	siblings := element.parent.children
+	for i, child := range siblings {
+		if child == element {
+			element.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+			break
+		}
+	}
+	element.parent = nil
+}
+
+func (element *Element) clear() {
+	for _, child := range element.children {
+		child.parent = nil
+	}
+	element.children = nil
+}
+
+// Remove the element from its parent.
+func (element *Element) Remove() *Element {
+	element.detach()
 	return element
 }
 
 func (element *Element) AddClass(class string) *Element {
-	element.classes.Call("add", class)
+	if !element.HasClass(class) {
+		element.classes = append(element.classes, class)
+	}
 	return element
 }
 
 func (element *Element) RemoveClass(class string) *Element {
-	element.classes.Call("remove", class)
+	for i, c := range element.classes {
+		if c == class {
+			element.classes = append(element.classes[:i:i], element.classes[i+1:]...)
+			break
+		}
+	}
 	return element
 }
 
 func (element *Element) ToggleClass(class string) *Element {
-	element.classes.Call("toggle", class)
-	return element
+	if element.HasClass(class) {
+		return element.RemoveClass(class)
+	}
+	return element.AddClass(class)
+}
+
+// HasClass reports whether the element has the class.
+func (element *Element) HasClass(class string) bool {
+	for _, c := range element.classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
 }
 
 func (element *Element) SetAttribute(attr string, v string) *Element {
-	element.Value.Call("setAttribute", attr, v)
+	if attr == "class" {
+		element.classes = strings.Fields(v)
+		return element
+	}
+	for i := range element.attributes {
+		if element.attributes[i].name == attr {
+			element.attributes[i].value = v
+			return element
+		}
+	}
+	element.attributes = append(element.attributes, attribute{attr, v})
 	return element
 }
 
+// Attribute returns the value of an attribute ("" if it is not set).
+func (element *Element) Attribute(attr string) string {
+	v, _ := element.attribute(attr)
+	return v
+}
+
+func (element *Element) attribute(attr string) (string, bool) {
+	if attr == "class" {
+		return strings.Join(element.classes, " "), len(element.classes) > 0
+	}
+	for _, a := range element.attributes {
+		if a.name == attr {
+			return a.value, true
+		}
+	}
+	return "", false
+}
+
 func (element *Element) Wrap(wrapper *Element, isInitialized bool) *Element {
 	if !isInitialized {
 		wrapper.Append(element)
 		return element
 	}
-	parentNode := element.Value.Get("parentNode")
-	parentNode.Call("insertBefore", *wrapper.Value, *element.Value)
-	wrapper.Value.Call("appendChild", *element.Value)
+	if element.parent != nil {
+		element.parent.insertBefore(wrapper, element)
+	}
+	wrapper.Append(element)
 	return element
 }
 
 func (element *Element) Unwrap(wrapper *Element) *Element {
-	parentNode := element.Value.Get("parentNode")
-	parentNode.Call("insertBefore", *element.Value, *wrapper.Value)
-	parentNode.Call("removeChild", *wrapper)
+	if wrapper.parent != nil {
+		wrapper.parent.insertBefore(element, wrapper)
+	}
+	wrapper.detach()
 	return element
 }
 
-func (element *Element) SetContent(v interface{}) *Element {
-	switch v := v.(type) {
-	case nil:
-		element.Set("innerHTML", "nil")
-	case string:
-		element.Set("innerHTML", v)
-	case int:
-		element.Set("innerHTML", strconv.Itoa(v))
-	default:
-		if e, ok := v.(Renderable); ok {
-			element.Append(e.Render())
-			break
+// Tag returns the lower case tag name of the element.
+func (element *Element) Tag() string {
+	return element.tag
+}
+
+// Parent returns the parent element (nil if the element is detached).
+func (element *Element) Parent() *Element {
+	return element.parent
+}
+
+// Children returns the child elements without text nodes.
+func (element *Element) Children() []*Element {
+	children := []*Element{}
+	for _, child := range element.children {
+		if !child.isText() {
+			children = append(children, child)
 		}
-		panic("type unknown")
 	}
-	return element
+	return children
+}
+
+func (element *Element) isText() bool {
+	return element.tag == "#text" || element.tag == "#html"
+}
+
+var markup = regexp.MustCompile(`<[^>]*>`)
+
+// Text returns the text content of the element, tags of markup assigned to
+// innerHTML are stripped.
+func (element *Element) Text() string {
+	switch element.tag {
+	case "#text":
+		return element.text
+	case "#html":
+		return html.UnescapeString(markup.ReplaceAllString(element.text, ""))
+	}
+	var b strings.Builder
+	for _, child := range element.children {
+		b.WriteString(child.Text())
+	}
+	return b.String()
+}
+
+// InnerHTML serializes the children of the element.
+func (element *Element) InnerHTML() string {
+	var b strings.Builder
+	for _, child := range element.children {
+		child.serialize(&b)
+	}
+	return b.String()
+}
+
+// OuterHTML serializes the element and its children.
+func (element *Element) OuterHTML() string {
+	var b strings.Builder
+	element.serialize(&b)
+	return b.String()
+}
+
+// voidElements have no closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
 }
 
-// TODO: callback return Value
-func (element *Element) OnInput(f func()) {
-	if element.onInputCallback != nil {
-		element.Value.Call("removeEventListener", "input", element.onInputCallback)
-		element.onInputCallback = nil
+func (element *Element) serialize(b *strings.Builder) {
+	switch element.tag {
+	case "#text":
+		b.WriteString(html.EscapeString(element.text))
+		return
+	case "#html":
+		b.WriteString(element.text)
+		return
+	}
+	b.WriteString("<" + element.tag)
+	if len(element.classes) > 0 {
+		b.WriteString(` class="` + html.EscapeString(strings.Join(element.classes, " ")) + `"`)
 	}
-	if f != nil {
-		element.onInputCallback = f
-		element.Value.Call("addEventListener", "input", element.onInputCallback)
+	for _, a := range element.attributes {
+		b.WriteString(" " + a.name + `="` + html.EscapeString(a.value) + `"`)
 	}
+	b.WriteString(">")
+	if voidElements[element.tag] {
+		return
+	}
+	for _, child := range element.children {
+		child.serialize(b)
+	}
+	b.WriteString("</" + element.tag + ">")
 }
 
-// TODO: callback return Value
-func (element *Element) OnClick(f func()) {
-	if element.onClickCallback != nil {
-		element.Value.Call("removeEventListener", "click", element.onClickCallback)
-		element.onClickCallback = nil
+// Query returns the first element below element matching the CSS selector
+// or nil.
+func (element *Element) Query(selector string) *Element {
+	s := parseSelector(selector)
+	var found *Element
+	element.walk(func(e *Element) bool {
+		if s.matches(e) {
+			found = e
+		}
+		return found == nil
+	})
+	return found
+}
+
+// QueryAll returns the elements below element matching the CSS selector.
+func (element *Element) QueryAll(selector string) []*Element {
+	s := parseSelector(selector)
+	elements := []*Element{}
+	element.walk(func(e *Element) bool {
+		if s.matches(e) {
+			elements = append(elements, e)
+		}
+		return true
+	})
+	return elements
+}
+
+// walk calls f for the descendants of the element in document order until
+// f returns false.
+func (element *Element) walk(f func(e *Element) bool) bool {
+	for _, child := range element.children {
+		if child.isText() {
+			continue
+		}
+		if !f(child) || !child.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// closest returns the element or its nearest ancestor matching the selector.
+func (element *Element) closest(selector string) *Element {
+	s := parseSelector(selector)
+	for e := element; e != nil; e = e.parent {
+		if s.matches(e) {
+			return e
+		}
+	}
+	return nil
+}
+
+// Boundary calls fallback (e.g. to replace the content of the element) if
+// an event callback of the element or one of its children panics.
+func (element *Element) Boundary(fallback func()) *Element {
+	element.SetAttribute(boundaryAttribute, "")
+	element.properties["flexkitBoundary"] = fallback
+	return element
+}
+
+// dispatch calls an event callback and triggers the nearest boundary if it
+// panics.
+func (element *Element) dispatch(f func()) {
+	if Guard(f) {
+		return
 	}
-	if f != nil {
-		element.onClickCallback = f
-		element.Value.Call("addEventListener", "click", element.onClickCallback)
+	if boundary := element.closest("[" + boundaryAttribute + "]"); boundary != nil {
+		if fallback, ok := boundary.properties["flexkitBoundary"].(func()); ok {
+			fallback()
+		}
 	}
 }
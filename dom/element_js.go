@@ -0,0 +1,161 @@
+package dom
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+)
+
+type Element struct {
+	Value   *js.Object
+	classes *js.Object
+
+	onClick ListenerHandle
+	onInput ListenerHandle
+
+	// listeners added with On, which move with the element when it is
+	// hydrated
+	listeners []*listenerEntry
+}
+
+func NewElement(t string) *Element {
+	value := DOC.Call("createElement", t)
+	classes := value.Get("classList")
+	element := &Element{
+		Value:   value,
+		classes: classes,
+	}
+	if hydrating != nil {
+		hydrating.register(element)
+	}
+	return element
+}
+
+// Query returns the first element matching the CSS selector or nil.
+func Query(selector string) *Element {
+	return wrap(DOC.Call("querySelector", selector))
+}
+
+// Query returns the first element below element matching the CSS selector
+// or nil.
+func (element *Element) Query(selector string) *Element {
+	return wrap(element.Value.Call("querySelector", selector))
+}
+
+// QueryAll returns the elements below element matching the CSS selector.
+func (element *Element) QueryAll(selector string) []*Element {
+	nodes := element.Value.Call("querySelectorAll", selector)
+	elements := make([]*Element, nodes.Length())
+	for i := range elements {
+		elements[i] = wrap(nodes.Index(i))
+	}
+	return elements
+}
+
+func getElement(t string) *Element {
+	documentHead := DOC.Get(t)
+	documentHeadClasses := documentHead.Get("classList")
+	return &Element{
+		Value:   documentHead,
+		classes: documentHeadClasses,
+	}
+}
+
+func (element *Element) Set(p string, x interface{}) *Element {
+	if p == "type" {
+		js.Global.Call("makePropertyWriteable", *element.Value, "type")
+	}
+	element.Value.Set(p, x)
+	return element
+}
+
+func (element *Element) Get(p string) *js.Object {
+	return element.Value.Get(p)
+}
+
+// GetString returns a property as string ("" if it is not set).
+func (element *Element) GetString(p string) string {
+	v := element.Value.Get(p)
+	if v == js.Undefined || v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// SetStyle sets an inline style property ("resize", "none").
+func (element *Element) SetStyle(property string, value string) *Element {
+	element.Value.Get("style").Call("setProperty", property, value)
+	return element
+}
+
+func (element *Element) Prepend(e *Element) *Element {
+	element.Value.Call("prepend", e.Value)
+	return element
+}
+
+func (element *Element) Append(e *Element) *Element {
+	element.Value.Call("append", *e.Value)
+	return element
+}
+
+// Remove the element from its parent.
+func (element *Element) Remove() *Element {
+	element.Value.Call("remove")
+	return element
+}
+
+func (element *Element) AddClass(class string) *Element {
+	element.classes.Call("add", class)
+	return element
+}
+
+func (element *Element) RemoveClass(class string) *Element {
+	element.classes.Call("remove", class)
+	return element
+}
+
+func (element *Element) ToggleClass(class string) *Element {
+	element.classes.Call("toggle", class)
+	return element
+}
+
+func (element *Element) SetAttribute(attr string, v string) *Element {
+	element.Value.Call("setAttribute", attr, v)
+	return element
+}
+
+func (element *Element) Wrap(wrapper *Element, isInitialized bool) *Element {
+	if !isInitialized {
+		wrapper.Append(element)
+		return element
+	}
+	parentNode := element.Value.Get("parentNode")
+	parentNode.Call("insertBefore", *wrapper.Value, *element.Value)
+	wrapper.Value.Call("appendChild", *element.Value)
+	return element
+}
+
+func (element *Element) Unwrap(wrapper *Element) *Element {
+	parentNode := wrapper.Value.Get("parentNode")
+	parentNode.Call("insertBefore", *element.Value, *wrapper.Value)
+	parentNode.Call("removeChild", *wrapper.Value)
+	return element
+}
+
+// Boundary calls fallback (e.g. to replace the content of the element) if
+// an event callback of the element or one of its children panics.
+func (element *Element) Boundary(fallback func()) *Element {
+	element.SetAttribute(boundaryAttribute, "")
+	element.Value.Set("flexkitBoundary", fallback)
+	return element
+}
+
+// dispatch calls an event callback and triggers the nearest boundary if it
+// panics.
+func (element *Element) dispatch(f func()) {
+	if Guard(f) {
+		return
+	}
+	boundary := element.Value.Call("closest", "["+boundaryAttribute+"]")
+	if boundary != nil && boundary.Get("flexkitBoundary") != js.Undefined {
+		boundary.Call("flexkitBoundary")
+	}
+}
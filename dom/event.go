@@ -0,0 +1,215 @@
+// +build !js
+
+package dom
+
+// Event is an event of the in-memory document, see NewEvent.
+type Event struct {
+	typ              string
+	init             EventInit
+	target           *Element
+	currentTarget    *Element
+	stopped          bool
+	defaultPrevented bool
+}
+
+func (e *Event) Type() string {
+	return e.typ
+}
+
+// Target returns the element the event was dispatched to.
+func (e *Event) Target() *Element {
+	return e.target
+}
+
+// CurrentTarget returns the element the listener is registered on.
+func (e *Event) CurrentTarget() *Element {
+	return e.currentTarget
+}
+
+// Key returns the key value of keyboard events ("a", "Enter", "ArrowUp").
+func (e *Event) Key() string {
+	return e.init.Key
+}
+
+// Code returns the physical key of keyboard events ("KeyA", "Digit1").
+func (e *Event) Code() string {
+	return e.init.Code
+}
+
+func (e *Event) CtrlKey() bool {
+	return e.init.CtrlKey
+}
+
+func (e *Event) AltKey() bool {
+	return e.init.AltKey
+}
+
+func (e *Event) ShiftKey() bool {
+	return e.init.ShiftKey
+}
+
+func (e *Event) MetaKey() bool {
+	return e.init.MetaKey
+}
+
+// Button returns the mouse button of mouse events (0 is the main button).
+func (e *Event) Button() int {
+	return e.init.Button
+}
+
+// ClientX returns the horizontal mouse position relative to the viewport.
+func (e *Event) ClientX() int {
+	return e.init.ClientX
+}
+
+// ClientY returns the vertical mouse position relative to the viewport.
+func (e *Event) ClientY() int {
+	return e.init.ClientY
+}
+
+// PageX returns the horizontal mouse position, the in-memory document does
+// not scroll.
+func (e *Event) PageX() int {
+	return e.init.ClientX
+}
+
+// PageY returns the vertical mouse position, the in-memory document does
+// not scroll.
+func (e *Event) PageY() int {
+	return e.init.ClientY
+}
+
+func (e *Event) PreventDefault() {
+	e.defaultPrevented = true
+}
+
+func (e *Event) StopPropagation() {
+	e.stopped = true
+}
+
+func (e *Event) DefaultPrevented() bool {
+	return e.defaultPrevented
+}
+
+// NewEvent creates a bubbling, cancelable event.
+func NewEvent(t string, init EventInit) *Event {
+	return &Event{typ: t, init: init}
+}
+
+// listener is a registered event listener, removed listeners are skipped
+// by dispatches which are already running.
+type listener struct {
+	f       func(e *Event)
+	options ListenerOptions
+	removed bool
+}
+
+// ListenerHandle removes a listener registered with Element.On or Listen,
+// the zero value is a no-op.
+type ListenerHandle struct {
+	target   *Element
+	event    string
+	listener *listener
+}
+
+// Remove the listener, removing it twice is a no-op.
+func (h ListenerHandle) Remove() {
+	if h.listener == nil || h.listener.removed {
+		return
+	}
+	h.listener.removed = true
+	listeners := h.target.listeners[h.event]
+	for i, l := range listeners {
+		if l == h.listener {
+			h.target.listeners[h.event] = append(listeners[:i:i], listeners[i+1:]...)
+			break
+		}
+	}
+}
+
+// On calls f for every event of type event dispatched to the element, a
+// panic in f triggers the nearest boundary (see Element.Boundary).
+func (element *Element) On(event string, f func(e *Event), options ...ListenerOptions) ListenerHandle {
+	return listen(element, event, func(e *Event) {
+		element.dispatch(func() {
+			f(e)
+		})
+	}, options)
+}
+
+// Listen calls f for every event of type event dispatched to target (e.g.
+// the document), panics in f are recovered (see Guard).
+func Listen(target *Element, event string, f func(e *Event), options ...ListenerOptions) ListenerHandle {
+	return listen(target, event, func(e *Event) {
+		Guard(func() {
+			f(e)
+		})
+	}, options)
+}
+
+func listen(target *Element, event string, f func(e *Event), options []ListenerOptions) ListenerHandle {
+	l := &listener{f: f}
+	if len(options) > 0 {
+		l.options = options[0]
+	}
+	target.listeners[event] = append(target.listeners[event], l)
+	return ListenerHandle{
+		target:   target,
+		event:    event,
+		listener: l,
+	}
+}
+
+// Dispatch e to the element and report whether it was not cancelled, the
+// listeners of the ancestors are called in the capture and bubble phase.
+func (element *Element) Dispatch(e *Event) bool {
+	e.target = element
+	path := []*Element{}
+	for ancestor := element.parent; ancestor != nil; ancestor = ancestor.parent {
+		path = append(path, ancestor)
+	}
+	for i := len(path) - 1; i >= 0 && !e.stopped; i-- {
+		path[i].invoke(e, true)
+	}
+	if !e.stopped {
+		element.invoke(e, true)
+	}
+	if !e.stopped {
+		element.invoke(e, false)
+	}
+	for _, ancestor := range path {
+		if e.stopped {
+			break
+		}
+		ancestor.invoke(e, false)
+	}
+	e.currentTarget = nil
+	return !e.defaultPrevented
+}
+
+// invoke calls the listeners of the phase, listeners of the target are
+// called in both phases by their capture option.
+func (element *Element) invoke(e *Event, capture bool) {
+	e.currentTarget = element
+	listeners := append([]*listener{}, element.listeners[e.typ]...)
+	for _, l := range listeners {
+		if l.removed || l.options.Capture != capture {
+			continue
+		}
+		if l.options.Once {
+			ListenerHandle{element, e.typ, l}.Remove()
+		}
+		if l.options.Passive {
+			e.callPassive(l.f)
+			continue
+		}
+		l.f(e)
+	}
+}
+
+// callPassive calls a passive listener, which cannot cancel the event.
+func (e *Event) callPassive(f func(e *Event)) {
+	prevented := e.defaultPrevented
+	f(e)
+	e.defaultPrevented = prevented
+}
@@ -0,0 +1,241 @@
+package dom
+
+import (
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// Event is a DOM event passed to the listeners registered with Element.On.
+type Event struct {
+	Value *js.Object
+}
+
+func (e *Event) Type() string {
+	return e.Value.Get("type").String()
+}
+
+// Target returns the element the event was dispatched to.
+func (e *Event) Target() *Element {
+	return wrap(e.Value.Get("target"))
+}
+
+// CurrentTarget returns the element the listener is registered on.
+func (e *Event) CurrentTarget() *Element {
+	return wrap(e.Value.Get("currentTarget"))
+}
+
+// Key returns the key value of keyboard events ("a", "Enter", "ArrowUp").
+func (e *Event) Key() string {
+	return e.string("key")
+}
+
+// Code returns the physical key of keyboard events ("KeyA", "Digit1").
+func (e *Event) Code() string {
+	return e.string("code")
+}
+
+func (e *Event) CtrlKey() bool {
+	return e.Value.Get("ctrlKey").Bool()
+}
+
+func (e *Event) AltKey() bool {
+	return e.Value.Get("altKey").Bool()
+}
+
+func (e *Event) ShiftKey() bool {
+	return e.Value.Get("shiftKey").Bool()
+}
+
+func (e *Event) MetaKey() bool {
+	return e.Value.Get("metaKey").Bool()
+}
+
+// Button returns the mouse button of mouse events (0 is the main button).
+func (e *Event) Button() int {
+	return e.int("button")
+}
+
+// ClientX returns the horizontal mouse position relative to the viewport.
+func (e *Event) ClientX() int {
+	return e.int("clientX")
+}
+
+// ClientY returns the vertical mouse position relative to the viewport.
+func (e *Event) ClientY() int {
+	return e.int("clientY")
+}
+
+// PageX returns the horizontal mouse position relative to the document.
+func (e *Event) PageX() int {
+	return e.int("pageX")
+}
+
+// PageY returns the vertical mouse position relative to the document.
+func (e *Event) PageY() int {
+	return e.int("pageY")
+}
+
+func (e *Event) PreventDefault() {
+	e.Value.Call("preventDefault")
+}
+
+func (e *Event) StopPropagation() {
+	e.Value.Call("stopPropagation")
+}
+
+func (e *Event) DefaultPrevented() bool {
+	return e.Value.Get("defaultPrevented").Bool()
+}
+
+func (e *Event) string(p string) string {
+	v := e.Value.Get(p)
+	if v == js.Undefined || v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+func (e *Event) int(p string) int {
+	v := e.Value.Get(p)
+	if v == js.Undefined || v == nil {
+		return 0
+	}
+	return v.Int()
+}
+
+// wrap returns the Element of a DOM node (nil for null).
+func wrap(value *js.Object) *Element {
+	if value == nil || value == js.Undefined {
+		return nil
+	}
+	return &Element{
+		Value:   value,
+		classes: value.Get("classList"),
+	}
+}
+
+// ListenerHandle removes a listener registered with Element.On or Listen,
+// the zero value is a no-op.
+type ListenerHandle struct {
+	// target of Listen, listeners of Element.On are removed from the
+	// current node of the element, which changes when it is hydrated
+	target  *js.Object
+	element *Element
+	entry   *listenerEntry
+}
+
+// listenerEntry is a listener added to a node.
+type listenerEntry struct {
+	event    string
+	listener *js.Object
+	options  ListenerOptions
+}
+
+// Remove the listener, removing it twice is a no-op.
+func (h ListenerHandle) Remove() {
+	if h.entry == nil {
+		return
+	}
+	target := h.target
+	if h.element != nil {
+		target = h.element.Value
+		h.element.forget(h.entry)
+	}
+	target.Call("removeEventListener", h.entry.event, h.entry.listener, js.M{"capture": h.entry.options.Capture})
+}
+
+// On calls f for every event of type event dispatched to the element, a
+// panic in f triggers the nearest boundary (see Element.Boundary).
+func (element *Element) On(event string, f func(e *Event), options ...ListenerOptions) ListenerHandle {
+	var h ListenerHandle
+	h = listen(element.Value, event, func(e *Event) {
+		if h.entry.options.Once {
+			// the browser removed the listener before calling it
+			element.forget(h.entry)
+		}
+		element.dispatch(func() {
+			f(e)
+		})
+	}, options)
+	h.target, h.element = nil, element
+	element.listeners = append(element.listeners, h.entry)
+	return h
+}
+
+// forget removes a listener from the listeners of the element.
+func (element *Element) forget(entry *listenerEntry) {
+	for i, l := range element.listeners {
+		if l == entry {
+			element.listeners = append(element.listeners[:i:i], element.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// Listen calls f for every event of type event dispatched to target (e.g.
+// the document or window), panics in f are recovered (see Guard).
+func Listen(target *js.Object, event string, f func(e *Event), options ...ListenerOptions) ListenerHandle {
+	return listen(target, event, func(e *Event) {
+		Guard(func() {
+			f(e)
+		})
+	}, options)
+}
+
+// NewEvent creates a bubbling, cancelable event, keyboard and mouse events
+// are created for "key..." and mouse or click types.
+func NewEvent(t string, init EventInit) *Event {
+	options := js.M{
+		"bubbles":    true,
+		"cancelable": true,
+		"key":        init.Key,
+		"code":       init.Code,
+		"ctrlKey":    init.CtrlKey,
+		"altKey":     init.AltKey,
+		"shiftKey":   init.ShiftKey,
+		"metaKey":    init.MetaKey,
+		"button":     init.Button,
+		"clientX":    init.ClientX,
+		"clientY":    init.ClientY,
+	}
+	constructor := "Event"
+	switch {
+	case strings.HasPrefix(t, "key"):
+		constructor = "KeyboardEvent"
+	case strings.HasPrefix(t, "mouse") || strings.HasSuffix(t, "click") || t == "contextmenu":
+		constructor = "MouseEvent"
+	}
+	return &Event{Value: js.Global.Get(constructor).New(t, options)}
+}
+
+// Dispatch e to the element and report whether it was not cancelled.
+func (element *Element) Dispatch(e *Event) bool {
+	return element.Value.Call("dispatchEvent", e.Value).Bool()
+}
+
+func listen(target *js.Object, event string, f func(e *Event), options []ListenerOptions) ListenerHandle {
+	entry := &listenerEntry{event: event}
+	if len(options) > 0 {
+		entry.options = options[0]
+	}
+	// the listener is created once, which makes it removable by reference
+	entry.listener = js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
+		f(&Event{Value: arguments[0]})
+		return nil
+	})
+	entry.add(target)
+	return ListenerHandle{
+		target: target,
+		entry:  entry,
+	}
+}
+
+// add the listener to target.
+func (entry *listenerEntry) add(target *js.Object) {
+	target.Call("addEventListener", entry.event, entry.listener, js.M{
+		"capture": entry.options.Capture,
+		"passive": entry.options.Passive,
+		"once":    entry.options.Once,
+	})
+}
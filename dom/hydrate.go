@@ -0,0 +1,28 @@
+// +build !js
+
+package dom
+
+// hydrating collects the callbacks of Adopted while Hydrate renders.
+var hydrating *hydration
+
+type hydration struct {
+	adopted []func()
+}
+
+// Hydrate replaces the children of parent with the element returned by
+// render, the in-memory document has no pre-rendered markup to adopt.
+func Hydrate(parent *Element, render func() *Element) *Element {
+	h := &hydration{}
+	hydrating = h
+	defer func() {
+		hydrating = nil
+	}()
+	root := render()
+	parent.Set("innerHTML", "")
+	parent.Append(root)
+	hydrating = nil
+	for _, f := range h.adopted {
+		f()
+	}
+	return root
+}
@@ -0,0 +1,198 @@
+package dom
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+)
+
+// hydrationKey is the property which links the nodes created while
+// hydrating to their elements.
+const hydrationKey = "flexkitNode"
+
+// hydrating records the elements created by the render function of Hydrate.
+var hydrating *hydration
+
+type hydration struct {
+	elements []*Element
+	adopted  []func()
+}
+
+func (h *hydration) register(element *Element) {
+	element.Value.Set(hydrationKey, len(h.elements))
+	h.elements = append(h.elements, element)
+}
+
+// element returns the element of a node created while hydrating (or nil).
+func (h *hydration) element(node *js.Object) *Element {
+	index := node.Get(hydrationKey)
+	if index == js.Undefined {
+		return nil
+	}
+	return h.elements[index.Int()]
+}
+
+// Hydrate adopts the markup below parent (e.g. pre-rendered on the server)
+// for the element returned by render instead of replacing it: matching
+// nodes are kept and patched where they differ, and the elements created by
+// render (and their listeners) are moved to them. Element nodes are matched
+// by their id or else by their tag in order. The children of parent which
+// have no counterpart are removed.
+//
+// The markup is replaced instead if the IDs of the rendered elements differ
+// from the ones in the markup, e.g. because the tree was built in another
+// order than on the server, adopting it would mix up the nodes.
+func Hydrate(parent *Element, render func() *Element) *Element {
+	h := &hydration{}
+	hydrating = h
+	defer func() {
+		hydrating = nil
+	}()
+	root := render()
+
+	fresh := DOC.Call("createElement", "div")
+	fresh.Call("appendChild", root.Value)
+	if match := findMatch(parent.Value.Get("firstChild"), root.Value); match != nil && sameIDs(match, root.Value) {
+		h.children(parent.Value, fresh)
+	} else {
+		parent.Set("innerHTML", "")
+		parent.Append(root)
+	}
+	for _, element := range h.elements {
+		element.Value.Delete(hydrationKey)
+	}
+	hydrating = nil
+	for _, f := range h.adopted {
+		f()
+	}
+	return root
+}
+
+// sameIDs reports whether existing and fresh and the elements below them
+// carry the same IDs in the same order.
+func sameIDs(existing, fresh *js.Object) bool {
+	if existing.Get("id").String() != fresh.Get("id").String() {
+		return false
+	}
+	a, b := existing.Call("querySelectorAll", "[id]"), fresh.Call("querySelectorAll", "[id]")
+	if a.Length() != b.Length() {
+		return false
+	}
+	for i := 0; i < a.Length(); i++ {
+		if a.Index(i).Get("id").String() != b.Index(i).Get("id").String() {
+			return false
+		}
+	}
+	return true
+}
+
+// children makes the child nodes of existing match the child nodes of fresh.
+func (h *hydration) children(existing, fresh *js.Object) {
+	nodes := fresh.Get("childNodes")
+	children := make([]*js.Object, nodes.Length())
+	for i := range children {
+		children[i] = nodes.Index(i)
+	}
+
+	cursor := existing.Get("firstChild")
+	for _, child := range children {
+		match := findMatch(cursor, child)
+		if match == nil {
+			existing.Call("insertBefore", child, cursor)
+			continue
+		}
+		for cursor != match {
+			next := cursor.Get("nextSibling")
+			existing.Call("removeChild", cursor)
+			cursor = next
+		}
+		h.node(match, child)
+		cursor = match.Get("nextSibling")
+	}
+	for cursor != nil {
+		next := cursor.Get("nextSibling")
+		existing.Call("removeChild", cursor)
+		cursor = next
+	}
+}
+
+// node patches existing to match fresh and moves the element of fresh to it.
+func (h *hydration) node(existing, fresh *js.Object) {
+	if fresh.Get("nodeType").Int() != elementNode {
+		if existing.Get("nodeValue").String() != fresh.Get("nodeValue").String() {
+			existing.Set("nodeValue", fresh.Get("nodeValue"))
+		}
+		return
+	}
+	patchAttributes(existing, fresh)
+	h.children(existing, fresh)
+	if element := h.element(fresh); element != nil {
+		adopt(element, existing)
+	}
+}
+
+const elementNode = 1
+
+// findMatch returns the first node from cursor on which fresh is hydrated
+// into: an element with the same id, else the next element with the same
+// tag. Other nodes only match at the cursor.
+func findMatch(cursor, fresh *js.Object) *js.Object {
+	if cursor == nil {
+		return nil
+	}
+	if fresh.Get("nodeType").Int() != elementNode {
+		if cursor.Get("nodeType").Int() == fresh.Get("nodeType").Int() {
+			return cursor
+		}
+		return nil
+	}
+	tag := fresh.Get("tagName").String()
+	if id := fresh.Get("id").String(); id != "" {
+		for node := cursor; node != nil; node = node.Get("nextSibling") {
+			if node.Get("nodeType").Int() == elementNode && node.Get("id").String() == id && node.Get("tagName").String() == tag {
+				return node
+			}
+		}
+	}
+	for node := cursor; node != nil; node = node.Get("nextSibling") {
+		if node.Get("nodeType").Int() == elementNode && node.Get("tagName").String() == tag {
+			return node
+		}
+	}
+	return nil
+}
+
+// patchAttributes sets the attributes of existing to the ones of fresh.
+func patchAttributes(existing, fresh *js.Object) {
+	names := existing.Call("getAttributeNames")
+	for i := 0; i < names.Length(); i++ {
+		name := names.Index(i).String()
+		if !fresh.Call("hasAttribute", name).Bool() {
+			existing.Call("removeAttribute", name)
+		}
+	}
+	names = fresh.Call("getAttributeNames")
+	for i := 0; i < names.Length(); i++ {
+		name := names.Index(i).String()
+		value := fresh.Call("getAttribute", name).String()
+		if !existing.Call("hasAttribute", name).Bool() || existing.Call("getAttribute", name).String() != value {
+			existing.Call("setAttribute", name, value)
+		}
+	}
+}
+
+// adopt moves the element to the node: its properties (e.g. the boundary
+// of Element.Boundary) and listeners are copied and it refers to the node
+// from now on, which keeps the references held by widgets valid.
+func adopt(element *Element, node *js.Object) {
+	fresh := element.Value
+	keys := js.Global.Get("Object").Call("keys", fresh)
+	for i := 0; i < keys.Length(); i++ {
+		if key := keys.Index(i).String(); key != hydrationKey {
+			node.Set(key, fresh.Get(key))
+		}
+	}
+	for _, entry := range element.listeners {
+		entry.add(node)
+	}
+	element.Value = node
+	element.classes = node.Get("classList")
+}
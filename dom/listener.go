@@ -0,0 +1,24 @@
+package dom
+
+// ListenerOptions are the options of addEventListener.
+type ListenerOptions struct {
+	// Capture calls the listener in the capture phase.
+	Capture bool
+	// Passive promises not to call PreventDefault (e.g. for scroll events).
+	Passive bool
+	// Once removes the listener after its first call.
+	Once bool
+}
+
+// EventInit holds the properties of an event created with NewEvent.
+type EventInit struct {
+	Key      string
+	Code     string
+	CtrlKey  bool
+	AltKey   bool
+	ShiftKey bool
+	MetaKey  bool
+	Button   int
+	ClientX  int
+	ClientY  int
+}
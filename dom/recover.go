@@ -0,0 +1,43 @@
+package dom
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// boundaryAttribute marks elements with a panic boundary, see Element.Boundary.
+const boundaryAttribute = "data-boundary"
+
+var (
+	panicHandler   func(value interface{}, stack []byte)
+	panicHandlerMu sync.Mutex
+)
+
+// OnPanic sets the handler for panics recovered from event callbacks and
+// guarded functions, panics are logged to the browser console in any case.
+func OnPanic(f func(value interface{}, stack []byte)) {
+	panicHandlerMu.Lock()
+	panicHandler = f
+	panicHandlerMu.Unlock()
+}
+
+// Guard calls f and recovers a panic, which is logged with its stack trace
+// and passed to the OnPanic handler. Guard reports whether f returned.
+func Guard(f func()) (ok bool) {
+	defer func() {
+		if value := recover(); value != nil {
+			ok = false
+			stack := debug.Stack()
+			logPanic(fmt.Sprintf("panic: %v\n\n%s", value, stack))
+			panicHandlerMu.Lock()
+			handler := panicHandler
+			panicHandlerMu.Unlock()
+			if handler != nil {
+				handler(value, stack)
+			}
+		}
+	}()
+	f()
+	return true
+}
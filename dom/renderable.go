@@ -1,5 +1,6 @@
-// +build !js
-
 package dom
 
-type Renderable interface {}
+type Renderable interface {
+	Render() *Element
+}
+
@@ -1,6 +0,0 @@
-package dom
-
-type Renderable interface {
-	Render() *Element
-}
-
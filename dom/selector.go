@@ -0,0 +1,216 @@
+// +build !js
+
+package dom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// selector is a parsed comma separated list of CSS selectors, the in-memory
+// document supports tags, ids, classes, attributes ([name] and [name=value],
+// the value optionally quoted) and the descendant and child combinators.
+// Other syntax panics like an invalid selector throws in the browser, rather
+// than silently matching the wrong elements.
+type selector [][]compound
+
+// compound is a simple selector like "div.uk-card[data-boundary]", child is
+// set if it is preceded by the child combinator.
+type compound struct {
+	tag        string
+	id         string
+	classes    []string
+	attributes []attributeSelector
+	child      bool
+}
+
+type attributeSelector struct {
+	name  string
+	value string
+	exact bool
+}
+
+func parseSelector(s string) selector {
+	p := &selectorParser{s: s}
+	var list selector
+	for {
+		chain := p.chain()
+		if len(chain) == 0 {
+			p.fail()
+		}
+		list = append(list, chain)
+		if p.done() {
+			return list
+		}
+		p.i++ // ','
+	}
+}
+
+// selectorParser reads a selector list from s, i is the current position.
+type selectorParser struct {
+	s string
+	i int
+}
+
+func (p *selectorParser) fail() {
+	panic(fmt.Sprintf("dom: unsupported selector %q", p.s))
+}
+
+func (p *selectorParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *selectorParser) skipSpace() {
+	for !p.done() && strings.IndexByte(" \t\n\r\f", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+// chain reads compounds and combinators up to the next ',' or the end.
+func (p *selectorParser) chain() []compound {
+	var chain []compound
+	child := false
+	for {
+		p.skipSpace()
+		if p.done() || p.s[p.i] == ',' {
+			if child {
+				p.fail()
+			}
+			return chain
+		}
+		if p.s[p.i] == '>' {
+			if len(chain) == 0 || child {
+				p.fail()
+			}
+			child = true
+			p.i++
+			continue
+		}
+		c := p.compound()
+		c.child, child = child, false
+		chain = append(chain, c)
+	}
+}
+
+func (p *selectorParser) compound() compound {
+	var c compound
+	start := p.i
+	for !p.done() {
+		switch ch := p.s[p.i]; {
+		case ch == '#':
+			p.i++
+			c.id = p.name()
+		case ch == '.':
+			p.i++
+			c.classes = append(c.classes, p.name())
+		case ch == '[':
+			c.attributes = append(c.attributes, p.attribute())
+		case ch == '*' && p.i == start:
+			p.i++
+		case isNameChar(ch) && p.i == start:
+			c.tag = strings.ToLower(p.name())
+		case strings.IndexByte(" \t\n\r\f>,", ch) >= 0:
+			return c
+		default:
+			p.fail()
+		}
+	}
+	return c
+}
+
+// attribute reads [name], [name=value] or [name="value"].
+func (p *selectorParser) attribute() attributeSelector {
+	p.i++ // '['
+	p.skipSpace()
+	a := attributeSelector{name: p.name()}
+	p.skipSpace()
+	if !p.done() && p.s[p.i] == '=' {
+		p.i++
+		p.skipSpace()
+		a.exact = true
+		if !p.done() && (p.s[p.i] == '"' || p.s[p.i] == '\'') {
+			end := strings.IndexByte(p.s[p.i+1:], p.s[p.i])
+			if end < 0 {
+				p.fail()
+			}
+			a.value = p.s[p.i+1 : p.i+1+end]
+			p.i += end + 2
+		} else {
+			a.value = p.name()
+		}
+		p.skipSpace()
+	}
+	if p.done() || p.s[p.i] != ']' {
+		p.fail()
+	}
+	p.i++
+	return a
+}
+
+// name reads an identifier (tag, id, class or attribute name).
+func (p *selectorParser) name() string {
+	start := p.i
+	for !p.done() && isNameChar(p.s[p.i]) {
+		p.i++
+	}
+	if p.i == start {
+		p.fail()
+	}
+	return p.s[start:p.i]
+}
+
+func isNameChar(ch byte) bool {
+	return ch == '-' || ch == '_' || ch >= 0x80 ||
+		('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z') || ('0' <= ch && ch <= '9')
+}
+
+// matches reports whether e matches one of the selectors of the list.
+func (s selector) matches(e *Element) bool {
+	for _, chain := range s {
+		if matchChain(chain, len(chain)-1, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchChain reports whether e matches chain[i] and its ancestors the
+// compounds before it.
+func matchChain(chain []compound, i int, e *Element) bool {
+	if !chain[i].matches(e) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	if chain[i].child {
+		return e.parent != nil && matchChain(chain, i-1, e.parent)
+	}
+	for ancestor := e.parent; ancestor != nil; ancestor = ancestor.parent {
+		if matchChain(chain, i-1, ancestor) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c compound) matches(e *Element) bool {
+	if e.isText() || (c.tag != "" && c.tag != e.tag) {
+		return false
+	}
+	if c.id != "" && e.Attribute("id") != c.id {
+		return false
+	}
+	for _, class := range c.classes {
+		if !e.HasClass(class) {
+			return false
+		}
+	}
+	for _, a := range c.attributes {
+		value, ok := e.attribute(a.name)
+		if !ok || (a.exact && value != a.value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+// +build !js
+
+package dom
+
+import "testing"
+
+func TestSelector(t *testing.T) {
+	root := NewElement("div").Append(
+		NewElement("ul").AddClass("list").
+			Append(NewElement("li")).
+			Append(NewElement("li").Append(NewElement("input").SetAttribute("placeholder", "First name"))))
+
+	tests := []struct {
+		selector string
+		expected int
+	}{
+		{"li", 2},
+		{"div > li", 0},
+		{"div > ul > li", 2},
+		{"ul > li", 2},
+		{"div>ul.list>li", 2},
+		{".list > li input", 1},
+		{"div li > input", 1},
+		{"ul, li", 3},
+		{`[placeholder="First name"]`, 1},
+		{`input[placeholder='First name']`, 1},
+		{`[placeholder="First"]`, 0},
+		{"[placeholder]", 1},
+	}
+	for _, test := range tests {
+		if got := len(root.QueryAll(test.selector)); got != test.expected {
+			t.Errorf("%s: expected %d elements, got %d", test.selector, test.expected, got)
+		}
+	}
+}
+
+func TestUnsupportedSelector(t *testing.T) {
+	for _, selector := range []string{"li:first-child", "ul + li", "ul ~ li", "[placeholder^=First]", `[placeholder="First]`, "ul >", "> li", "ul,,li", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q did not panic", selector)
+				}
+			}()
+			NewElement("div").QueryAll(selector)
+		}()
+	}
+}
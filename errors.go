@@ -0,0 +1,67 @@
+// +build js
+
+package flexkit
+
+import (
+	"errors"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+var (
+	// ErrNotStarted is returned when navigating before App.Start was called.
+	ErrNotStarted = errors.New("flexkit: app not started")
+	// ErrStarted is returned when starting an app which is already running.
+	ErrStarted = errors.New("flexkit: app already started")
+	// ErrViewExists is returned when a view name or pattern is registered twice.
+	ErrViewExists = errors.New("flexkit: view does already exist")
+	// ErrViewNotFound is returned when no view matches a name or path.
+	ErrViewNotFound = errors.New("flexkit: view does not exist")
+	// ErrNavigationCancelled is returned when a BeforeLeave hook of the
+	// active view cancels the navigation.
+	ErrNavigationCancelled = errors.New("flexkit: navigation cancelled")
+	// ErrMountNotFound is returned when the mount target of an app does not exist.
+	ErrMountNotFound = errors.New("flexkit: mount target does not exist")
+	// ErrInvalidShortcut is returned for key combinations which cannot be parsed.
+	ErrInvalidShortcut = errors.New("flexkit: invalid keyboard shortcut")
+	// ErrUnknownIcon is returned for icon data which is neither PNG, SVG nor ICO.
+	ErrUnknownIcon = errors.New("flexkit: unknown icon format")
+)
+
+// OnError sets the handler for errors of functions which do not return them
+// (e.g. Goto, App.View or navigation by URL), errors are logged to the
+// browser console by default.
+func (a *App) OnError(f func(err error)) *App {
+	a.onError = f
+	return a
+}
+
+// OnPanic sets the handler for panics recovered from event callbacks and
+// item boundaries (see flex.Item.Boundary). The handler is shared by all
+// apps, panics are logged to the browser console in any case.
+func (a *App) OnPanic(f func(value interface{}, stack []byte)) *App {
+	dom.OnPanic(f)
+	return a
+}
+
+// navigated drops ErrNavigationCancelled, a cancelled navigation is no
+// error for the functions which report errors instead of returning them.
+func navigated(err error) error {
+	if errors.Is(err, ErrNavigationCancelled) {
+		return nil
+	}
+	return err
+}
+
+// report err to the error handler of the app (or the console if a is nil).
+func (a *App) report(err error) {
+	if err == nil {
+		return
+	}
+	if a != nil && a.onError != nil {
+		a.onError(err)
+		return
+	}
+	js.Global.Get("console").Call("error", err.Error())
+}
@@ -0,0 +1,216 @@
+// +build js
+
+package flexkit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/png"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Icon is an image used as favicon or apple touch icon.
+type Icon struct {
+	href  string
+	mime  string
+	sizes string
+}
+
+// NewIcon creates an icon from PNG, SVG or ICO data, the type and the sizes
+// are read from the data.
+func NewIcon(data []byte) (*Icon, error) {
+	mime, sizes, err := sniffIcon(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Icon{
+		href:  "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data),
+		mime:  mime,
+		sizes: sizes,
+	}, nil
+}
+
+// NewImageIcon creates a PNG icon from img.
+func NewImageIcon(img image.Image) (*Icon, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return NewIcon(buf.Bytes())
+}
+
+// Sizes overrides the sizes of the icon ("16x16 32x32" or "any").
+func (i *Icon) Sizes(sizes string) *Icon {
+	i.sizes = sizes
+	return i
+}
+
+// Href returns the data URI of the icon.
+func (i *Icon) Href() string {
+	return i.href
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// sniffIcon returns the MIME type and the sizes of icon data.
+func sniffIcon(data []byte) (mime string, sizes string, err error) {
+	switch {
+	case bytes.HasPrefix(data, pngSignature) && len(data) >= 24:
+		width := binary.BigEndian.Uint32(data[16:20])
+		height := binary.BigEndian.Uint32(data[20:24])
+		return "image/png", fmt.Sprintf("%dx%d", width, height), nil
+	case len(data) >= 6 && bytes.Equal(data[:4], []byte{0, 0, 1, 0}):
+		count := int(binary.LittleEndian.Uint16(data[4:6]))
+		all := []string{}
+		for i := 0; i < count && 6+16*i+2 <= len(data); i++ {
+			width, height := int(data[6+16*i]), int(data[6+16*i+1])
+			if width == 0 {
+				width = 256
+			}
+			if height == 0 {
+				height = 256
+			}
+			all = append(all, fmt.Sprintf("%dx%d", width, height))
+		}
+		return "image/x-icon", strings.Join(all, " "), nil
+	case isSVG(data):
+		return "image/svg+xml", "any", nil
+	}
+	return "", "", ErrUnknownIcon
+}
+
+func isSVG(data []byte) bool {
+	text := bytes.TrimSpace(data)
+	if bytes.HasPrefix(text, []byte("<?xml")) || bytes.HasPrefix(text, []byte("<!--")) || bytes.HasPrefix(text, []byte("<!DOCTYPE")) {
+		return bytes.Contains(text, []byte("<svg"))
+	}
+	return bytes.HasPrefix(text, []byte("<svg"))
+}
+
+var (
+	// icon links added to the document head and the favicon badge
+	iconLinks  []*js.Object
+	touchLinks []*js.Object
+	favicons   []*Icon
+	badge      string
+	badges     int
+	iconsMu    sync.Mutex
+)
+
+// Favicon as base64 href entry ("data:image/png;base64,iVBORw0KGgoAAAA...").
+func (a *App) Favicon(href string) *App {
+	return a.Favicons(&Icon{href: href})
+}
+
+// Favicons replaces the favicons of the document, pass one icon per size
+// and the browser picks the best matching one.
+func (a *App) Favicons(icons ...*Icon) *App {
+	iconsMu.Lock()
+	favicons = icons
+	iconLinks = replaceLinks(iconLinks, "icon", icons)
+	label, generation := badge, badges
+	iconsMu.Unlock()
+	if label != "" {
+		drawBadge(label, generation)
+	}
+	return a
+}
+
+// AppleTouchIcons replaces the icons used by iOS for home screen shortcuts.
+func (a *App) AppleTouchIcons(icons ...*Icon) *App {
+	iconsMu.Lock()
+	touchLinks = replaceLinks(touchLinks, "apple-touch-icon", icons)
+	iconsMu.Unlock()
+	return a
+}
+
+// FaviconBadge draws label (e.g. an unread count) onto the favicons, an
+// empty label removes the badge.
+func (a *App) FaviconBadge(label string) *App {
+	iconsMu.Lock()
+	badge = label
+	badges++
+	generation := badges
+	iconsMu.Unlock()
+	drawBadge(label, generation)
+	return a
+}
+
+// replaceLinks removes the previous links and adds a link for every icon.
+func replaceLinks(previous []*js.Object, rel string, icons []*Icon) []*js.Object {
+	for _, link := range previous {
+		link.Call("remove")
+	}
+	links := []*js.Object{}
+	for i := len(icons) - 1; i >= 0; i-- {
+		link := dom.DOC.Call("createElement", "link")
+		link.Set("rel", rel)
+		link.Set("href", icons[i].href)
+		if icons[i].mime != "" {
+			link.Set("type", icons[i].mime)
+		}
+		if icons[i].sizes != "" {
+			link.Call("setAttribute", "sizes", icons[i].sizes)
+		}
+		dom.DOC.Get("head").Call("prepend", link)
+		links = append([]*js.Object{link}, links...)
+	}
+	return links
+}
+
+// badgeSize is the size of the canvas the badge is drawn on.
+const badgeSize = 64
+
+// drawBadge draws the label onto every favicon, the result is dropped if
+// another badge was requested in the meantime.
+func drawBadge(label string, generation int) {
+	iconsMu.Lock()
+	links, icons := iconLinks, favicons
+	iconsMu.Unlock()
+
+	for i, link := range links {
+		icon, link := icons[i], link
+		if label == "" {
+			link.Set("type", icon.mime)
+			link.Set("href", icon.href)
+			continue
+		}
+		img := js.Global.Get("Image").New()
+		img.Set("onload", func() {
+			iconsMu.Lock()
+			current := badges == generation
+			iconsMu.Unlock()
+			if !current {
+				return
+			}
+			canvas := dom.DOC.Call("createElement", "canvas")
+			canvas.Set("width", badgeSize)
+			canvas.Set("height", badgeSize)
+			ctx := canvas.Call("getContext", "2d")
+			ctx.Call("drawImage", img, 0, 0, badgeSize, badgeSize)
+
+			radius := badgeSize * 0.3
+			ctx.Set("fillStyle", "#f0506e")
+			ctx.Call("beginPath")
+			ctx.Call("arc", badgeSize-radius, radius, radius, 0, 2*math.Pi)
+			ctx.Call("fill")
+			ctx.Set("fillStyle", "#fff")
+			ctx.Set("font", "bold 26px sans-serif")
+			ctx.Set("textAlign", "center")
+			ctx.Set("textBaseline", "middle")
+			ctx.Call("fillText", label, badgeSize-radius, radius+1, radius*2)
+
+			link.Set("type", "image/png")
+			link.Set("href", canvas.Call("toDataURL", "image/png"))
+		})
+		img.Set("src", icon.href)
+	}
+}
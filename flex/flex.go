@@ -1,57 +1,37 @@
 package flex
 
 import (
+	"strconv"
 	"sync"
-	"math/rand"
 )
 
-
-
+// IDs are generated in sequence, a tree built in the same order (e.g. on
+// the server and in the browser) gets the same IDs, which lets the browser
+// hydrate pre-rendered markup.
 var (
-	idRunes       = []rune("abcdefghijklmnopqrstuvwxyz")
-	idLength      = 8
-	idRetries     = 100
-	generatedIDs  = []string{}
-	generatedIDs_ sync.Mutex
+	idPrefix = "flex"
+	nextID   = 0
+	nextIDMu sync.Mutex
 )
 
 func generateUniqueID() string {
-	generatedIDs_.Lock()
-	for i := 0; i < idRetries; i++ {
-		id := generateID()
-		idIsUnique := true
-		for _, existingID := range generatedIDs {
-			if existingID == id {
-				idIsUnique = false
-				break
-			}
-		}
-		if idIsUnique {
-			generatedIDs_.Unlock()
-			return id
-		}
-	}
-	generatedIDs_.Unlock()
-	panic("error generating unique id")
-}
-
-func freeID(id string) {
-	generatedIDs_.Lock()
-	for i, existingID := range generatedIDs {
-		if existingID == id {
-			generatedIDs = append(generatedIDs[:i], generatedIDs[i+1:]...)
-			break
-		}
-	}
-	generatedIDs_.Unlock()
+	nextIDMu.Lock()
+	nextID++
+	id := idPrefix + strconv.Itoa(nextID)
+	nextIDMu.Unlock()
+	return id
 }
 
-func generateID() string {
-	b := make([]rune, idLength)
-	for i := range b {
-		b[i] = idRunes[rand.Intn(len(idRunes))]
-	}
-	return string(b)
+// ResetIDs restarts the sequence of generated IDs. Call it before building
+// a tree which is rendered on the server (see package ssr), so its IDs
+// match the ones of the same tree built by the app in the browser, markup
+// with other IDs is replaced instead of adopted (see dom.Hydrate). Trees
+// built before with the same IDs must not be rendered into the same
+// document.
+func ResetIDs() {
+	nextIDMu.Lock()
+	nextID = 0
+	nextIDMu.Unlock()
 }
 
 type ScreenSize string
@@ -148,5 +128,3 @@ func (fa FlexAlignment) alignSelf() string {
 	}
 	return ""
 }
-
-
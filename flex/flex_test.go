@@ -8,5 +8,5 @@ import (
 
 func TestFlex(t *testing.T) {
 	c := NewContainer().Wrap(Wrap).JustifyContent(SpaceBetween, ScreenSizeLarge).Append(NewItem(nil).Grow(1))
-	fmt.Println(c.Style())
+	fmt.Println(c.CSS())
 }
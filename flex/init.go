@@ -8,11 +8,15 @@ func init() {
 }
 
 const CSS = `
-body > :first-child {
+.flex-body {
 	height: 100vh;
 	width: 100vw;
 }
-body > :first-child > * {
+.flex-root {
+	height: 100%;
+	width: 100%;
+}
+.flex-body > *, .flex-root > * {
 	flex-grow: 0;
 	flex-shrink: 0;
 	flex-basis: 0;
@@ -33,7 +37,7 @@ const scrollbarCSS = `
 ::-webkit-scrollbar-track{
 	-webkit-box-shadow: inset 0 0 6px rgba(0,0,0,0.3);
 	border-radius: 10px;
-	background-color: #F5F5F5;
+	background-color: var(--flexkit-surface, #F5F5F5);
 }
 ::-webkit-scrollbar {
 	width: 12px;
@@ -41,5 +45,5 @@ const scrollbarCSS = `
 ::-webkit-scrollbar-thumb{
 	border-radius: 10px;
 	-webkit-box-shadow: inset 0 0 6px rgba(0,0,0,.3);
-	background-color: #D62929;
+	background-color: var(--flexkit-primary, #D62929);
 }`
@@ -19,6 +19,9 @@ type Item struct {
 	expandHeight bool
 	renderable   dom.Renderable
 
+	// shown in place of the item if it panics
+	boundary dom.Renderable
+
 	// hide/show behavior (usable after intialization)
 	mutex  sync.Mutex
 	hidden bool
@@ -244,6 +247,13 @@ func (i *Item) Align(v FlexAlignment, sizes ...ScreenSize) *Item {
 	return i
 }
 
+// Boundary shows fallback in place of the item if rendering it or one of
+// its event callbacks panics, the panic is passed to dom.OnPanic.
+func (i *Item) Boundary(fallback dom.Renderable) *Item {
+	i.boundary = fallback
+	return i
+}
+
 // Expand item width and height.
 func (i *Item) Expand() *Item {
 	i.expandHeight = true
@@ -295,9 +305,9 @@ func (i *Item) Hide() *Item {
 func (i *Item) renderHidden() {
 	if i.ref != nil {
 		if i.hidden {
-			i.ref.RemoveClass("hidden")
-		} else {
 			i.ref.AddClass("hidden")
+		} else {
+			i.ref.RemoveClass("hidden")
 		}
 	}
 }
@@ -0,0 +1,85 @@
+package flex
+
+import (
+	"github.com/satnamram/flexkit/dom"
+)
+
+func (c *Container) Render() *dom.Element {
+	containerDiv := dom.NewElement("div").Set("id", c.id)
+
+	styleTag := dom.NewElement("style")
+	styleTag.Set("innerHTML", c.CSS())
+	containerDiv.Append(styleTag)
+
+	for _, item := range c.items {
+
+		var itemRoot *dom.Element
+		if item.boundary == nil {
+			itemRoot = item.renderable.Render()
+		} else if !dom.Guard(func() { itemRoot = item.renderable.Render() }) {
+			itemRoot = item.boundary.Render()
+		}
+		if item.expandWidth {
+			itemRoot.AddClass("expand-width")
+		}
+		if item.expandHeight {
+			itemRoot.AddClass("expand-height")
+		}
+
+		itemWrapper := dom.NewElement("div").
+			Set("id", item.id).
+			Append(itemRoot)
+		if item.boundary != nil {
+			fallback := item.boundary
+			itemWrapper.Boundary(func() {
+				itemWrapper.Set("innerHTML", "")
+				itemWrapper.Append(fallback.Render())
+			})
+		}
+
+
+		// hold item reference and enforce show/hide
+		item.mutex.Lock()
+		item.ref = itemWrapper
+		if item.hidden {
+			item.ref.AddClass("hidden")
+		}
+		item.mutex.Unlock()
+
+		containerDiv.Append(itemWrapper)
+	}
+	return containerDiv
+}
+
+// RenderInto replaces the content of el with the rendered container, the
+// document outside of el is left untouched.
+func (c *Container) RenderInto(el *dom.Element) *dom.Element {
+	root := c.Render().AddClass("flex-root")
+	el.Set("innerHTML", "")
+	el.Append(root)
+	return root
+}
+
+// RenderToBody replaces the content of the body with the rendered container,
+// which fills the viewport.
+func (c *Container) RenderToBody() *dom.Element {
+	root := c.Padding("0px").Margin("0px").Render().AddClass("flex-body")
+	dom.BODY.Set("innerHTML", "")
+	dom.BODY.Append(root)
+	return root
+}
+
+// HydrateInto is like RenderInto but adopts the markup in el, e.g. of the
+// same container pre-rendered on the server, see dom.Hydrate.
+func (c *Container) HydrateInto(el *dom.Element) *dom.Element {
+	return dom.Hydrate(el, func() *dom.Element {
+		return c.Render().AddClass("flex-root")
+	})
+}
+
+// HydrateBody is like RenderToBody but adopts the markup of the body.
+func (c *Container) HydrateBody() *dom.Element {
+	return dom.Hydrate(dom.BODY, func() *dom.Element {
+		return c.Padding("0px").Margin("0px").Render().AddClass("flex-body")
+	})
+}
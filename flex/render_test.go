@@ -0,0 +1,165 @@
+// +build !js
+
+package flex
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/kit"
+)
+
+type label string
+
+func (l label) Render() *dom.Element {
+	return dom.NewElement("span").Set("textContent", string(l))
+}
+
+type broken struct{}
+
+func (broken) Render() *dom.Element {
+	panic("broken")
+}
+
+func TestRender(t *testing.T) {
+	hidden := NewItem(label("b")).Hide()
+	c := NewContainer().
+		Append(NewItem(label("a")).ExpandWidth()).
+		Append(hidden).
+		Append(NewItem(broken{}).Boundary(label("fallback")))
+	root := c.RenderInto(dom.BODY)
+	defer dom.Reset()
+
+	if root.Attribute("id") != c.id || !root.HasClass("flex-root") {
+		t.Fatalf("unexpected root %s", root.OuterHTML())
+	}
+	if style := root.Query("style"); style == nil || style.Text() != c.CSS() {
+		t.Fatal("container style is missing")
+	}
+	items := root.QueryAll("#" + c.id + " > div")
+	if len(items) != 3 {
+		t.Fatalf("got %d items, want 3", len(items))
+	}
+	if span := items[0].Query("span.expand-width"); span == nil || span.Text() != "a" {
+		t.Errorf("unexpected first item %s", items[0].OuterHTML())
+	}
+	if !items[1].HasClass("hidden") {
+		t.Error("hidden item is shown")
+	}
+	hidden.Show()
+	if items[1].HasClass("hidden") {
+		t.Error("shown item is hidden")
+	}
+	if items[2].Text() != "fallback" {
+		t.Errorf("boundary fallback not rendered: %s", items[2].OuterHTML())
+	}
+}
+
+func TestBoundary(t *testing.T) {
+	button := dom.NewElement("button")
+	button.OnClick(func() {
+		panic("click")
+	})
+	c := NewContainer().Append(NewItem(element{button}).Boundary(label("fallback")))
+	root := c.RenderInto(dom.BODY)
+	defer dom.Reset()
+
+	recovered := 0
+	dom.OnPanic(func(value interface{}, stack []byte) {
+		recovered++
+	})
+	defer dom.OnPanic(nil)
+	button.Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if recovered != 1 {
+		t.Errorf("recovered %d panics, want 1", recovered)
+	}
+	if root.Query("button") != nil || root.Query("span").Text() != "fallback" {
+		t.Errorf("item not replaced by fallback: %s", root.OuterHTML())
+	}
+}
+
+type element struct {
+	e *dom.Element
+}
+
+func (e element) Render() *dom.Element {
+	return e.e
+}
+
+func TestResetIDs(t *testing.T) {
+	build := func() *Container {
+		return NewContainer().Append(NewItem(label("a")).Grow(1))
+	}
+	ResetIDs()
+	first := build()
+	ResetIDs()
+	second := build()
+	if first.CSS() != second.CSS() || first.id == build().id {
+		t.Errorf("IDs are not generated in sequence: %s, %s", first.id, second.id)
+	}
+}
+
+func TestHydrateInto(t *testing.T) {
+	defer dom.Reset()
+	dom.BODY.Append(dom.NewElement("div").Set("id", "spinner"))
+	c := NewContainer().Append(NewItem(label("a")))
+	root := c.HydrateInto(dom.BODY)
+	if children := dom.BODY.Children(); len(children) != 1 || children[0] != root {
+		t.Errorf("unexpected body %s", dom.BODY.InnerHTML())
+	}
+	if !root.HasClass("flex-root") || root.Query("span").Text() != "a" {
+		t.Errorf("unexpected root %s", root.OuterHTML())
+	}
+}
+
+func TestHydrateSortable(t *testing.T) {
+	defer dom.Reset()
+	build := func() *Container {
+		return NewContainer().Append(NewItem(kit.NewTable().Header("Name").Append("Grace").Append("Ada")))
+	}
+	ResetIDs()
+	build().RenderInto(dom.BODY)
+	ResetIDs()
+	root := build().HydrateInto(dom.BODY)
+
+	first := func() string {
+		return root.Query("tbody td").Text()
+	}
+	root.Query("th").Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if first() != "Ada" {
+		t.Errorf("hydrated table was not sorted: %s", root.Query("tbody").OuterHTML())
+	}
+	root.Query("th").Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if first() != "Grace" {
+		t.Errorf("second click did not reverse the order: %s", root.Query("tbody").OuterHTML())
+	}
+}
+
+func TestRenderIntoLeavesPage(t *testing.T) {
+	defer dom.Reset()
+	header := dom.NewElement("header").AddClass("site")
+	mount := dom.NewElement("div").Set("id", "app").AddClass("content")
+	dom.BODY.Append(header)
+	dom.BODY.Append(mount)
+
+	root := NewContainer().Append(NewItem(label("a"))).RenderInto(mount)
+	if header.OuterHTML() != `<header class="site"></header>` {
+		t.Errorf("page element changed: %s", header.OuterHTML())
+	}
+	if mount.Attribute("class") != "content" || mount.Attribute("id") != "app" || root.Parent() != mount {
+		t.Errorf("mount target changed: %s", mount.OuterHTML())
+	}
+	// the global rules only select the elements flex renders
+	for _, selector := range []string{"\nbody", ":first-child"} {
+		if strings.Contains(CSS, selector) {
+			t.Errorf("flex.css selects %q", selector)
+		}
+	}
+	if root.HasClass("flex-body") {
+		t.Error("root rendered into a mount target fills the viewport")
+	}
+	if body := NewContainer().RenderToBody(); !body.HasClass("flex-body") {
+		t.Error("root rendered into the body does not fill the viewport")
+	}
+}
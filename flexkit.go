@@ -1,139 +1,479 @@
-// Package flexkit implements a programmatic user interface for single page applications.
+// +build js
+
 package flexkit
 
 import (
+	"encoding/json"
 	"github.com/gopherjs/gopherjs/js"
-	"time"
+	"fmt"
+	"strings"
 	"sync"
+	"context"
 	"github.com/satnamram/flexkit/flex"
 	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/kit"
+)
+
+// application is the most recently started app, it is used by the package
+// level navigation functions.
+var (
+	application   *App
+	applicationMu sync.Mutex
+	appCount      int
 )
 
-var application *App
+func defaultApp() *App {
+	applicationMu.Lock()
+	defer applicationMu.Unlock()
+	return application
+}
 
+// Goto pushes the view (or view path like "customers/42") onto the view stack.
 func Goto(view string) {
-	if application == nil {
-		panic("flexkit not initialized")
-	}
-	application.mutex.Lock()
-	for _, v := range application.views {
-		if v.name == view {
-			application.vstack = append(application.vstack, v.name)
-			v.container.RenderToBody()
-			break
-		}
-	}
-	application.mutex.Unlock()
+	GotoPath(view)
+}
+
+// GotoPath pushes the view matching path ("customers/42?tab=orders") onto
+// the view stack, path and query parameters are passed to the view factory.
+func GotoPath(path string) {
+	a := defaultApp()
+	a.report(navigated(a.GotoPath(path)))
+}
+
+// GotoRoute pushes the view registered with pattern ("customers/:id") onto
+// the view stack, params not used by the pattern are passed as query.
+func GotoRoute(pattern string, params Params) {
+	a := defaultApp()
+	a.report(navigated(a.GotoRoute(pattern, params)))
 }
 
+// Back pops the current view from the view stack.
 func Back() {
-	if application == nil {
-		panic("flexkit not initialized")
+	a := defaultApp()
+	a.report(navigated(a.Back()))
+}
+
+// Goto pushes the view (or view path like "customers/42") onto the view stack.
+func (a *App) Goto(view string) error {
+	return a.GotoPath(view)
+}
+
+// GotoPath pushes the view matching path ("customers/42?tab=orders") onto
+// the view stack, path and query parameters are passed to the view factory.
+// ErrNavigationCancelled is returned if the active view cancels leaving it
+// (see View.BeforeLeave).
+func (a *App) GotoPath(path string) error {
+	if !a.started() {
+		return ErrNotStarted
 	}
-	application.mutex.Lock()
+	path = strings.Trim(path, "/")
+	t := a.match(path)
+	if t == nil {
+		return fmt.Errorf("%w: '%s'", ErrViewNotFound, path)
+	}
+	if !a.canLeave(t) {
+		return ErrNavigationCancelled
+	}
+	a.mutex.Lock()
+	a.vstack = append(a.vstack, path)
+	a.history.push(len(a.vstack)-1, path)
+	a.mutex.Unlock()
+	a.enter(t)
+	return nil
+}
+
+// GotoRoute pushes the view registered with pattern ("customers/:id") onto
+// the view stack, params not used by the pattern are passed as query.
+func (a *App) GotoRoute(pattern string, params Params) error {
+	return a.GotoPath(parseRoute(pattern).path(params))
+}
+
+// Back closes the top most modal or pops the current view from the view
+// stack, ErrNavigationCancelled is returned if the active view cancels
+// leaving it.
+func (a *App) Back() error {
+	if !a.started() {
+		return ErrNotStarted
+	}
+	if a.CloseModal(nil) {
+		return nil
+	}
+	a.mutex.Lock()
 
 	// no parent - nothing to do
-	if len(application.vstack) <= 1 {
-		application.mutex.Unlock()
-		return
+	if len(a.vstack) <= 1 {
+		a.mutex.Unlock()
+		return nil
+	}
+
+	// if the parent is unknown (e.g. after a reload) the browser history
+	// has to tell us where to go
+	t := a.match(a.vstack[len(a.vstack)-2])
+	if t == nil {
+		a.history.back()
+		a.mutex.Unlock()
+		return nil
 	}
-	application.vstack = application.vstack[:len(application.vstack)-1]
+	a.mutex.Unlock()
 
 	// goto parent
-	for _, v := range application.views {
-		if v.name == application.vstack[len(application.vstack)-1] {
-			v.container.RenderToBody()
-			break
-		}
+	if !a.canLeave(t) {
+		return ErrNavigationCancelled
 	}
-	application.mutex.Unlock()
+	a.mutex.Lock()
+	a.vstack = a.vstack[:len(a.vstack)-1]
+	a.history.back()
+	a.mutex.Unlock()
+	t.pop = true
+	a.enter(t)
+	return nil
 }
 
 type App struct {
-	mutex sync.Mutex
+	mutex  sync.Mutex
+	id     int
+	ctx    context.Context
+	cancel context.CancelFunc
+	root   *dom.Element
+	kept   *cachedView
+	cache  *viewCache
+	target interface{}
+	mount  *dom.Element
+
+	// adopt the pre-rendered markup of the mount target on Start
+	hydrate bool
 
 	views  []*appView
 	vstack []string
+	active *activeView
+
+	routing Routing
+	base    string
+	history *history
+
+	transition      Transition
+	transitions     int
+	onTransitionEnd func()
+
+	titleTemplate string
+
+	// open modals, keydown traps the focus and handles Escape
+	modals  []*Modal
+	keydown dom.ListenerHandle
+
+	notificationPosition kit.NotificationPosition
+
+	shortcuts   []*shortcut
+	keyListener dom.ListenerHandle
+
+	// persisted view stack and the snapshots of its views
+	storage        Storage
+	storageKey     string
+	storageVersion int
+	snapshots      map[string]json.RawMessage
+	unload         dom.ListenerHandle
+
+	// set while the theme follows the preferred color scheme
+	themeQuery    *js.Object
+	themeListener *js.Object
+
+	onError func(err error)
 }
 
 type appView struct {
-	name      string
-	container *flex.Container
+	name  string
+	route route
+	view  *View
 }
 
 func Init() *App {
-	a := &App{
-		views: []*appView{},
+	applicationMu.Lock()
+	appCount++
+	id := appCount
+	applicationMu.Unlock()
+	return &App{
+		id:      id,
+		views:   []*appView{},
+		routing: RoutingHash,
+		base:    "/",
+		cache:   newViewCache(10),
+
+		notificationPosition: kit.NotificationTopCenter,
 	}
-	a.mutex.Lock() // lock until start
-	return a
 }
 
 func (a *App) View(name string, container *flex.Container) *App {
-	for _, view := range a.views {
-		if view.name == name {
-			panic("view '" + name + "' does already exist")
-		}
+	return a.Add(name, NewView(container))
+}
+
+// LazyView registers a view whose container is built on the first visit.
+func (a *App) LazyView(name string, build func() *flex.Container) *App {
+	return a.Add(name, NewLazyView(build))
+}
+
+// Route registers a view for a pattern like "customers/:id", the factory
+// builds the container from the path and query parameters on every visit.
+func (a *App) Route(pattern string, factory func(params Params) *flex.Container) *App {
+	return a.Add(pattern, NewViewFactory(factory))
+}
+
+// Add registers a view for a name or pattern like "customers/:id".
+func (a *App) Add(pattern string, v *View) *App {
+	a.report(a.Register(pattern, v))
+	return a
+}
+
+// Register registers a view for a name or pattern like "customers/:id" and
+// returns ErrViewExists if the pattern is already taken. Static segments
+// take precedence over parameters regardless of the registration order,
+// "customers/new" is matched before "customers/:id". The view is registered
+// but an error is returned if one of its shortcuts is invalid.
+func (a *App) Register(pattern string, v *View) error {
+	pattern = strings.Trim(pattern, "/")
+	if a.view(pattern) != nil {
+		return fmt.Errorf("%w: '%s'", ErrViewExists, pattern)
 	}
 	a.views = append(a.views, &appView{
-		name:      name,
-		container: container,
+		name:  pattern,
+		route: parseRoute(pattern),
+		view:  v,
 	})
-	return a
+	return v.registered(a)
 }
 
-func (a *App) Start(initalView string) {
+func (a *App) view(name string) *appView {
 	for _, view := range a.views {
-		if view.name == initalView {
-
-			// startup, unlock and block
-			a.vstack = append(a.vstack, initalView)
-			view.container.RenderToBody()
-			application = a
-			application.mutex.Unlock()
-			for {
-				time.Sleep(60 * time.Minute)
-			}
+		if view.name == name {
+			return view
 		}
 	}
-	panic("view '" + initalView + "' does not exist!")
+	return nil
 }
 
-func (a *App) Title(t string) *App {
-	dom.DOC.Set("title", t)
+// MountTo renders the views into the element (*dom.Element or CSS selector)
+// instead of replacing the document body, markup outside of it is left
+// untouched. If several apps share a page only one of them should use
+// routing, the others should be set to RoutingNone.
+func (a *App) MountTo(target interface{}) *App {
+	a.target = target
 	return a
 }
 
-var currentFavicon *js.Object
+// Hydrate makes Start adopt the markup of the mount target (or body), e.g.
+// pre-rendered on the server with package ssr, for the first view instead
+// of replacing it: the nodes are kept, differences patched and the event
+// listeners attached, which avoids a flicker on the first paint. The markup
+// is matched by the IDs of the flex containers and items, see flex.ResetIDs.
+func (a *App) Hydrate(enabled bool) *App {
+	a.mutex.Lock()
+	a.hydrate = enabled
+	a.mutex.Unlock()
+	return a
+}
 
-// Favicon as base64 href entry ("data:image/png;base64,iVBORw0KGgoAAAA...").
-func (a *App) Favicon(href string) *App {
-	head := dom.DOC.Get("head")
-	if currentFavicon != nil {
-		head.Call("removeChild", *currentFavicon)
+func (a *App) resolveMount() (*dom.Element, error) {
+	switch target := a.target.(type) {
+	case nil:
+		return nil, nil
+	case *dom.Element:
+		return target, nil
+	case string:
+		if el := dom.Query(target); el != nil {
+			return el, nil
+		}
+		return nil, fmt.Errorf("%w: '%s'", ErrMountNotFound, target)
 	}
-	faviconLink := dom.DOC.Call("createElement", "link")
-	currentFavicon = faviconLink
-	faviconLink.Set("rel", "shortcut icon")
-	faviconLink.Set("href", href)
-	dom.DOC.Get("head").Call("prepend", faviconLink)
+	return nil, fmt.Errorf("%w: %T", ErrMountNotFound, a.target)
+}
+
+// Routing sets how the view stack is mirrored into the browser URL (default: RoutingHash).
+func (a *App) Routing(r Routing) *App {
+	a.routing = r
 	return a
 }
 
-func (a *App) DarkTheme(backgroundColor string) *App {
-	if backgroundColor == "default" || backgroundColor == "" {
-		backgroundColor = "#222"
+// BasePath sets the path the application is served from (RoutingPath only, default: "/").
+func (a *App) BasePath(base string) *App {
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
 	}
-	dom.DOC.Get("documentElement").Get("style").Set("background-color", backgroundColor)
-	dom.BODY.AddClass("uk-light")
+	a.base = base
 	return a
 }
 
-func (a *App) LightTheme(backgroundColor string) *App {
-	if backgroundColor == "default" || backgroundColor == "" {
-		backgroundColor = "#f8f8f8"
+// Start renders the view referenced by the current URL, or initalView if the
+// URL does not reference a view, and returns. ErrViewNotFound is returned if
+// initalView does not exist.
+func (a *App) Start(initalView string) error {
+	return a.StartContext(context.Background(), initalView)
+}
+
+// StartContext is like Start but stops the app once ctx is done. The context
+// is the parent of all view contexts.
+func (a *App) StartContext(ctx context.Context, initalView string) error {
+	initalView = strings.Trim(initalView, "/")
+	t := a.match(initalView)
+	if t == nil {
+		return fmt.Errorf("%w: '%s'", ErrViewNotFound, initalView)
+	}
+	mount, err := a.resolveMount()
+	if err != nil {
+		return err
+	}
+
+	a.mutex.Lock()
+	if a.history != nil {
+		a.mutex.Unlock()
+		return ErrStarted
+	}
+	a.mount = mount
+	a.ctx, a.cancel = context.WithCancel(ctx)
+	a.history = newHistory(a)
+
+	depth, located := 0, false
+	if p, d, ok := a.history.location(); ok {
+		if location := a.match(p); location != nil {
+			t, depth, located = location, d, true
+		}
 	}
-	dom.DOC.Get("documentElement").Get("style").Set("background-color", backgroundColor)
-	dom.BODY.RemoveClass("uk-light")
+	a.vstack = make([]string, depth+1)
+	a.vstack[depth] = t.path
+
+	// a persisted stack completes the stack of the URL or, without one,
+	// replaces the initial view and is pushed to the browser history
+	stack, loadErr := a.loadStack()
+	if stack != nil && !located {
+		stack = known(stack)
+		depth = len(stack) - 1
+		t, a.vstack = a.match(stack[depth]), stack
+		for i, path := range stack[:depth] {
+			if i == 0 {
+				a.history.replace(i, path)
+			} else {
+				a.history.push(i, path)
+			}
+		}
+		if depth > 0 {
+			a.history.push(depth, t.path)
+		} else {
+			a.history.replace(depth, t.path)
+		}
+	} else {
+		if len(stack) == depth+1 && stack[depth] == t.path {
+			a.vstack = stack
+		}
+		a.history.replace(depth, t.path)
+	}
+	a.history.listen()
+	a.listenShortcuts()
+	a.listenUnload()
+	a.mutex.Unlock()
+	a.report(loadErr)
+
+	applicationMu.Lock()
+	application = a
+	applicationMu.Unlock()
+
+	a.enter(t)
+	go func() {
+		<-a.ctx.Done()
+		a.Stop()
+	}()
+	return nil
+}
+
+// Stop leaves the active view, removes the rendered view from the document
+// and stops listening to the browser history and the preferred color
+// scheme (see AutoTheme). A stopped app can be started again.
+func (a *App) Stop() {
+	a.mutex.Lock()
+	if a.history == nil {
+		a.mutex.Unlock()
+		return
+	}
+	h, active, root, cancel := a.history, a.active, a.root, a.cancel
+	a.history, a.active, a.root, a.kept, a.vstack = nil, nil, nil, nil, nil
+	a.cache.clear()
+	a.mutex.Unlock()
+	a.closeModals()
+
+	applicationMu.Lock()
+	if application == a {
+		application = nil
+	}
+	applicationMu.Unlock()
+
+	h.close()
+	a.mutex.Lock()
+	a.closeShortcuts()
+	a.closeUnload()
+	a.stopAutoTheme()
+	a.mutex.Unlock()
+	if active != nil {
+		active.leave()
+	}
+	if root != nil {
+		root.Remove()
+	}
+	cancel()
+}
+
+func (a *App) started() bool {
+	if a == nil {
+		return false
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.history != nil
+}
+
+// restore the view stack to the history entry the browser navigated to.
+func (a *App) restore(depth int, path string) {
+	a.mutex.Lock()
+	current := len(a.vstack) - 1
+	if depth == current && a.vstack[depth] == path {
+		a.mutex.Unlock()
+		return
+	}
+
+	// the browser back button closes the top most modal first
+	if depth < current && len(a.modals) > 0 {
+		a.history.revert(depth, current, a.vstack[current])
+		a.mutex.Unlock()
+		a.CloseModal(nil)
+		return
+	}
+	a.mutex.Unlock()
+	t := a.match(path)
+	if t == nil {
+		a.report(fmt.Errorf("%w: '%s'", ErrViewNotFound, path))
+		return
+	}
+
+	// move the browser back to the active entry if the view vetoes
+	if !a.canLeave(t) {
+		a.mutex.Lock()
+		a.history.revert(depth, current, a.vstack[current])
+		a.mutex.Unlock()
+		return
+	}
+
+	a.mutex.Lock()
+	if depth < len(a.vstack) {
+		a.vstack = a.vstack[:depth+1]
+	} else {
+		for len(a.vstack) < depth {
+			a.vstack = append(a.vstack, "")
+		}
+		a.vstack = append(a.vstack, "")
+	}
+	a.vstack[depth] = path
+	a.mutex.Unlock()
+	t.pop = depth < current
+	a.enter(t)
+}
+
+func (a *App) Title(t string) *App {
+	dom.DOC.Set("title", t)
 	return a
 }
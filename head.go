@@ -0,0 +1,92 @@
+// +build js
+
+package flexkit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/satnamram/flexkit/dom"
+)
+
+type viewMeta struct {
+	name    string
+	content string
+}
+
+// Title sets the document title while the view is shown.
+func (v *View) Title(title string) *View {
+	v.title = func(params Params) string {
+		return title
+	}
+	return v
+}
+
+// TitleFunc sets the document title from the route parameters while the
+// view is shown (e.g. "Customer 42").
+func (v *View) TitleFunc(f func(params Params) string) *View {
+	v.title = f
+	return v
+}
+
+// Meta sets a <meta> tag while the view is shown, names containing a colon
+// (e.g. "og:title") are set as property. Tags replaced by the view are
+// restored when it is left.
+func (v *View) Meta(name, content string) *View {
+	v.meta = append(v.meta, viewMeta{
+		name:    name,
+		content: content,
+	})
+	return v
+}
+
+// TitleTemplate formats the titles of views, e.g. "%s – MyApp".
+func (a *App) TitleTemplate(format string) *App {
+	a.mutex.Lock()
+	a.titleTemplate = format
+	a.mutex.Unlock()
+	return a
+}
+
+// applyHead sets the title and meta tags of the view and returns the
+// function restoring the previous ones.
+func applyHead(v *View, params Params, template string) func() {
+	restore := []func(){}
+	if v.title != nil {
+		previous := dom.DOC.Get("title").String()
+		title := v.title(params)
+		if template != "" {
+			title = fmt.Sprintf(template, title)
+		}
+		dom.DOC.Set("title", title)
+		restore = append(restore, func() {
+			dom.DOC.Set("title", previous)
+		})
+	}
+	for _, meta := range v.meta {
+		attribute := "name"
+		if strings.Contains(meta.name, ":") {
+			attribute = "property"
+		}
+		if tag := dom.Query("meta[" + attribute + "=\"" + meta.name + "\"]"); tag != nil {
+			previous := tag.Get("content").String()
+			tag.SetAttribute("content", meta.content)
+			restore = append(restore, func() {
+				tag.SetAttribute("content", previous)
+			})
+			continue
+		}
+		tag := dom.NewElement("meta").
+			SetAttribute(attribute, meta.name).
+			SetAttribute("content", meta.content)
+		dom.HEAD.Append(tag)
+		restore = append(restore, func() {
+			tag.Remove()
+		})
+	}
+	return func() {
+		for i := len(restore) - 1; i >= 0; i-- {
+			restore[i]()
+		}
+	}
+}
@@ -0,0 +1,204 @@
+// +build js
+
+package flexkit
+
+import (
+	"strings"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Routing defines how the view stack is mirrored into the browser URL.
+type Routing string
+
+const (
+	// RoutingHash stores the current view in the URL fragment ("#/settings").
+	RoutingHash Routing = "hash"
+	// RoutingPath stores the current view in the URL path ("/settings"). The
+	// server has to answer every view path with the application page.
+	RoutingPath Routing = "path"
+	// RoutingNone keeps the view stack in memory only.
+	RoutingNone Routing = "none"
+)
+
+// history mirrors the view stack of an App into the browser history. Every
+// entry pushed by flexkit carries its depth in the view stack, which lets
+// popstate events be translated into back and forward navigation.
+type history struct {
+	app      *App
+	routing  Routing
+	base     string
+	listener dom.ListenerHandle
+
+	// api is false if the History API is not available, in which case
+	// the URL hash is used and hashchange events drive navigation
+	api bool
+}
+
+func newHistory(a *App) *history {
+	h := &history{
+		app:     a,
+		routing: a.routing,
+		base:    a.base,
+		api:     js.Global.Get("history").Get("pushState") != js.Undefined,
+	}
+	if !h.api && h.routing == RoutingPath {
+		h.routing = RoutingHash
+	}
+	return h
+}
+
+func (h *history) enabled() bool {
+	return h != nil && h.routing != RoutingNone
+}
+
+func (h *history) url(name string) string {
+	if h.routing == RoutingPath {
+		return h.base + name
+	}
+	return "#/" + name
+}
+
+func (h *history) state(depth int, name string) js.M {
+	return js.M{
+		"flexkit": h.app.id,
+		"depth":   depth,
+		"view":    name,
+	}
+}
+
+// push adds a history entry for the view at depth.
+func (h *history) push(depth int, name string) {
+	if !h.enabled() {
+		return
+	}
+	if h.api {
+		js.Global.Get("history").Call("pushState", h.state(depth, name), "", h.url(name))
+		return
+	}
+	js.Global.Get("location").Set("hash", "/"+name)
+}
+
+// replace the current history entry with the view at depth.
+func (h *history) replace(depth int, name string) {
+	if !h.enabled() {
+		return
+	}
+	if h.api {
+		js.Global.Get("history").Call("replaceState", h.state(depth, name), "", h.url(name))
+		return
+	}
+	js.Global.Get("location").Call("replace", h.url(name))
+}
+
+// back moves the browser history one entry back.
+func (h *history) back() {
+	if !h.enabled() {
+		return
+	}
+	js.Global.Get("history").Call("back")
+}
+
+// revert moves the browser from the entry at depth back to the active entry.
+func (h *history) revert(depth, active int, name string) {
+	if !h.enabled() {
+		return
+	}
+	if h.api {
+		js.Global.Get("history").Call("go", active-depth)
+		return
+	}
+	js.Global.Get("location").Set("hash", "/"+name)
+}
+
+// location returns the view referenced by the current URL and its depth in
+// the view stack (if the entry was pushed by flexkit before a reload).
+func (h *history) location() (name string, depth int, ok bool) {
+	if !h.enabled() {
+		return "", 0, false
+	}
+	loc := js.Global.Get("location")
+	if h.routing == RoutingPath {
+		name = strings.TrimPrefix(loc.Get("pathname").String(), h.base) + loc.Get("search").String()
+	} else {
+		name = strings.TrimPrefix(strings.TrimPrefix(loc.Get("hash").String(), "#"), "/")
+	}
+	if name == "" {
+		return "", 0, false
+	}
+	if h.api {
+		state := js.Global.Get("history").Get("state")
+		if h.owns(state) {
+			depth = state.Get("depth").Int()
+		}
+	}
+	return name, depth, true
+}
+
+// listen translates popstate (or hashchange) events into view stack changes.
+func (h *history) listen() {
+	if !h.enabled() {
+		return
+	}
+	h.listener = dom.Listen(js.Global, h.event(), func(event *dom.Event) {
+		if h.api {
+			h.onPopState(event)
+		} else {
+			h.onHashChange(event)
+		}
+	})
+}
+
+// close stops listening to the browser history.
+func (h *history) close() {
+	h.listener.Remove()
+	h.listener = dom.ListenerHandle{}
+}
+
+func (h *history) event() string {
+	if h.api {
+		return "popstate"
+	}
+	return "hashchange"
+}
+
+// owns reports whether the history state was pushed by this app.
+func (h *history) owns(state *js.Object) bool {
+	return state != nil && state != js.Undefined && state.Get("flexkit").Int() == h.app.id
+}
+
+func (h *history) onPopState(event *dom.Event) {
+	state := event.Value.Get("state")
+	if h.owns(state) {
+		h.app.restore(state.Get("depth").Int(), state.Get("view").String())
+		return
+	}
+
+	// entry was not pushed by flexkit (e.g. edited URL), treat it as a new view
+	name, _, ok := h.location()
+	if !ok {
+		return
+	}
+	h.app.mutex.Lock()
+	depth := len(h.app.vstack)
+	h.app.mutex.Unlock()
+	h.replace(depth, name)
+	h.app.restore(depth, name)
+}
+
+func (h *history) onHashChange(event *dom.Event) {
+	name, _, ok := h.location()
+	if !ok {
+		return
+	}
+	h.app.mutex.Lock()
+	depth := len(h.app.vstack)
+	if depth > 1 && h.app.vstack[depth-2] == name {
+		depth -= 2
+	} else if h.app.vstack[depth-1] == name {
+		depth--
+	}
+	h.app.mutex.Unlock()
+	h.app.restore(depth, name)
+}
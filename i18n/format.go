@@ -0,0 +1,152 @@
+package i18n
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateStyle selects one of the date formats of a locale.
+type DateStyle int
+
+const (
+	DateShort DateStyle = iota
+	DateMedium
+	DateLong
+)
+
+// format holds the number and date conventions of a language.
+type format struct {
+	decimal string
+	group   string
+
+	// date patterns of the styles, see formatDate
+	dates  [3]string
+	months [12]string
+}
+
+var englishMonths = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+
+var formats = map[string]*format{
+	"en":    {".", ",", [3]string{"M/d/yyyy", "MMM d, yyyy", "MMMM d, yyyy"}, englishMonths},
+	"en-GB": {".", ",", [3]string{"dd/MM/yyyy", "d MMM yyyy", "d MMMM yyyy"}, englishMonths},
+	"de": {",", ".", [3]string{"dd.MM.yyyy", "d. MMM yyyy", "d. MMMM yyyy"},
+		[12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}},
+	"de-CH": {".", "’", [3]string{"dd.MM.yyyy", "d. MMM yyyy", "d. MMMM yyyy"},
+		[12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}},
+	"fr": {",", " ", [3]string{"dd/MM/yyyy", "d MMM yyyy", "d MMMM yyyy"},
+		[12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}},
+	"es": {",", ".", [3]string{"d/M/yyyy", "d MMM yyyy", "d 'de' MMMM 'de' yyyy"},
+		[12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}},
+	"it": {",", ".", [3]string{"dd/MM/yyyy", "d MMM yyyy", "d MMMM yyyy"},
+		[12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"}},
+	"nl": {",", ".", [3]string{"dd-MM-yyyy", "d MMM yyyy", "d MMMM yyyy"},
+		[12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"}},
+}
+
+// formatOf returns the conventions of the current locale (English if unknown).
+func formatOf() *format {
+	l := Locale()
+	if f, ok := formats[l]; ok {
+		return f
+	}
+	if f, ok := formats[language(l)]; ok {
+		return f
+	}
+	return formats["en"]
+}
+
+// FormatNumber formats v with decimals digits after the decimal separator
+// and grouped thousands.
+func FormatNumber(v float64, decimals int) string {
+	f := formatOf()
+	s := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	grouped := ""
+	for len(integer) > 3 {
+		grouped = f.group + integer[len(integer)-3:] + grouped
+		integer = integer[:len(integer)-3]
+	}
+	grouped = integer + grouped
+	if fraction != "" {
+		grouped += f.decimal + fraction
+	}
+	if v < 0 && strings.Trim(s, "0.") != "" {
+		grouped = "-" + grouped
+	}
+	return grouped
+}
+
+// FormatDate formats t in the given style of the current locale.
+func FormatDate(t time.Time, style DateStyle) string {
+	f := formatOf()
+	if style < DateShort || style > DateLong {
+		style = DateShort
+	}
+	return formatDate(t, f.dates[style], f.months)
+}
+
+// formatDate formats t by a pattern of d, dd (day), M, MM, MMM, MMMM (month)
+// and yy, yyyy (year), text in single quotes is copied as is.
+func formatDate(t time.Time, pattern string, months [12]string) string {
+	var b strings.Builder
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		if c == '\'' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			b.WriteString(string(runes[i+1 : end]))
+			i = end + 1
+			continue
+		}
+		n := 1
+		for i+n < len(runes) && runes[i+n] == c {
+			n++
+		}
+		switch {
+		case c == 'd' && n == 1:
+			b.WriteString(strconv.Itoa(t.Day()))
+		case c == 'd':
+			b.WriteString(pad(t.Day()))
+		case c == 'M' && n == 1:
+			b.WriteString(strconv.Itoa(int(t.Month())))
+		case c == 'M' && n == 2:
+			b.WriteString(pad(int(t.Month())))
+		case c == 'M' && n == 3:
+			b.WriteString(abbreviate(months[t.Month()-1]))
+		case c == 'M':
+			b.WriteString(months[t.Month()-1])
+		case c == 'y' && n == 2:
+			b.WriteString(pad(t.Year() % 100))
+		case c == 'y':
+			b.WriteString(strconv.Itoa(t.Year()))
+		default:
+			b.WriteString(string(runes[i : i+n]))
+		}
+		i += n
+	}
+	return b.String()
+}
+
+func pad(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// abbreviate shortens a month name to three letters.
+func abbreviate(month string) string {
+	runes := []rune(month)
+	if len(runes) <= 3 {
+		return month
+	}
+	return string(runes[:3])
+}
@@ -0,0 +1,202 @@
+// Package i18n translates messages and formats numbers and dates for the
+// current locale. Translations are registered per locale, e.g. from JSON:
+//
+//	{
+//		"save": "Speichern",
+//		"greeting": "Hallo {name}",
+//		"items": {"one": "{count} Eintrag", "other": "{count} Einträge"},
+//		"settings": {"title": "Einstellungen"}
+//	}
+//
+// Objects whose keys are plural categories hold the plural forms of a
+// message, other objects group messages ("settings.title").
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Args are the values of the placeholders of a message ("{name}").
+type Args map[string]interface{}
+
+// message is a translation with its plural forms (Other if not plural).
+type message map[Plural]string
+
+var (
+	catalogs = map[string]map[string]message{}
+	locale   = "en"
+	fallback = "en"
+	mutex    sync.Mutex
+)
+
+// Load registers the messages of a JSON catalog for locale, messages
+// already registered for the same keys are replaced.
+func Load(locale string, data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("i18n: catalog '%s': %w", locale, err)
+	}
+	messages := map[string]message{}
+	if err := flatten("", raw, messages); err != nil {
+		return fmt.Errorf("i18n: catalog '%s': %w", locale, err)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	catalog := catalogFor(locale)
+	for key, m := range messages {
+		catalog[key] = m
+	}
+	return nil
+}
+
+// flatten adds the messages of raw to messages, keys of nested groups are
+// prefixed with the key of the group.
+func flatten(prefix string, raw map[string]json.RawMessage, messages map[string]message) error {
+	for key, value := range raw {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			messages[prefix+key] = message{Other: text}
+			continue
+		}
+		var group map[string]json.RawMessage
+		if err := json.Unmarshal(value, &group); err != nil {
+			return fmt.Errorf("invalid message '%s'", prefix+key)
+		}
+		if forms, ok := pluralForms(group); ok {
+			messages[prefix+key] = forms
+			continue
+		}
+		if err := flatten(prefix+key+".", group, messages); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pluralForms returns the group as message if all its keys are plural
+// categories with a text.
+func pluralForms(group map[string]json.RawMessage) (message, bool) {
+	forms := message{}
+	for key, value := range group {
+		var text string
+		if !isPlural(Plural(key)) || json.Unmarshal(value, &text) != nil {
+			return nil, false
+		}
+		forms[Plural(key)] = text
+	}
+	return forms, len(forms) > 0
+}
+
+// Add registers messages for locale.
+func Add(locale string, messages map[string]string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	catalog := catalogFor(locale)
+	for key, text := range messages {
+		catalog[key] = message{Other: text}
+	}
+}
+
+func catalogFor(locale string) map[string]message {
+	locale = normalize(locale)
+	if catalogs[locale] == nil {
+		catalogs[locale] = map[string]message{}
+	}
+	return catalogs[locale]
+}
+
+// normalize converts "de_CH" and "de-ch" to "de-CH".
+func normalize(locale string) string {
+	parts := strings.SplitN(strings.Replace(locale, "_", "-", -1), "-", 2)
+	if len(parts) == 2 {
+		return strings.ToLower(parts[0]) + "-" + strings.ToUpper(parts[1])
+	}
+	return strings.ToLower(parts[0])
+}
+
+// language returns the language of a locale ("de" for "de-CH").
+func language(locale string) string {
+	return strings.SplitN(locale, "-", 2)[0]
+}
+
+// SetLocale switches the current locale and updates the rendered texts.
+func SetLocale(l string) {
+	mutex.Lock()
+	locale = normalize(l)
+	mutex.Unlock()
+	refreshDocument()
+}
+
+// Locale returns the current locale.
+func Locale() string {
+	mutex.Lock()
+	defer mutex.Unlock()
+	return locale
+}
+
+// SetFallback sets the locale used for messages missing in the current
+// locale (default: "en").
+func SetFallback(l string) {
+	mutex.Lock()
+	fallback = normalize(l)
+	mutex.Unlock()
+}
+
+// lookup returns the message for key in the current locale, its language
+// or the fallback locale.
+func lookup(key string) (message, string, bool) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, l := range []string{locale, language(locale), fallback, language(fallback)} {
+		if m, ok := catalogs[l][key]; ok {
+			return m, l, true
+		}
+	}
+	return nil, locale, false
+}
+
+// Translate returns the message for key with its placeholders replaced by
+// args, the key itself is returned if there is no translation.
+func Translate(key string, args Args) string {
+	m, _, ok := lookup(key)
+	if !ok {
+		return interpolate(key, args)
+	}
+	return interpolate(m[Other], args)
+}
+
+// TranslatePlural returns the plural form of the message for count, the
+// placeholder "{count}" is replaced by the formatted count.
+func TranslatePlural(key string, count int, args Args) string {
+	m, l, ok := lookup(key)
+	if !ok {
+		return interpolate(key, withCount(args, count))
+	}
+	text, ok := m[pluralOf(l, count)]
+	if !ok {
+		text = m[Other]
+	}
+	return interpolate(text, withCount(args, count))
+}
+
+func withCount(args Args, count int) Args {
+	all := Args{"count": FormatNumber(float64(count), 0)}
+	for key, value := range args {
+		all[key] = value
+	}
+	return all
+}
+
+func interpolate(text string, args Args) string {
+	if len(args) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	replacements := make([]string, 0, len(args)*2)
+	for key, value := range args {
+		replacements = append(replacements, "{"+key+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(text)
+}
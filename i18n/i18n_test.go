@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTranslate(t *testing.T) {
+	err := Load("de", []byte(`{
+		"greeting": "Hallo {name}",
+		"items": {"one": "{count} Eintrag", "other": "{count} Einträge"},
+		"settings": {"title": "Einstellungen"}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetLocale("de_CH")
+	defer SetLocale("en")
+
+	tests := []struct {
+		got      string
+		expected string
+	}{
+		{T("greeting").Args(Args{"name": "Ada"}).String(), "Hallo Ada"},
+		{T("items").Count(1).String(), "1 Eintrag"},
+		{T("items").Count(1200).String(), "1’200 Einträge"},
+		{T("settings.title").String(), "Einstellungen"},
+		{T("missing").String(), "missing"},
+	}
+	for _, test := range tests {
+		if test.got != test.expected {
+			t.Errorf("expected '%s', got '%s'", test.expected, test.got)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		locale   string
+		n        int
+		expected Plural
+	}{
+		{"en", 0, Other},
+		{"fr", 0, One},
+		{"ru", 21, One},
+		{"ru", 22, Few},
+		{"ru", 12, Many},
+		{"pl", 5, Many},
+		{"ja", 1, Other},
+	}
+	for _, test := range tests {
+		if got := pluralOf(test.locale, test.n); got != test.expected {
+			t.Errorf("%s %d: expected %s, got %s", test.locale, test.n, test.expected, got)
+		}
+	}
+}
+
+func TestFormat(t *testing.T) {
+	date := time.Date(2019, time.March, 4, 0, 0, 0, 0, time.UTC)
+	if got := FormatDate(time.Date(2019, time.July, 4, 0, 0, 0, 0, time.UTC), DateMedium); got != "Jul 4, 2019" {
+		t.Errorf("unexpected date '%s'", got)
+	}
+	SetLocale("de")
+	defer SetLocale("en")
+	if got := FormatNumber(-1234567.891, 2); got != "-1.234.567,89" {
+		t.Errorf("unexpected number '%s'", got)
+	}
+	if got := FormatDate(date, DateLong); got != "4. März 2019" {
+		t.Errorf("unexpected date '%s'", got)
+	}
+	SetLocale("es")
+	if got := FormatDate(date, DateLong); got != "4 de marzo de 2019" {
+		t.Errorf("unexpected date '%s'", got)
+	}
+}
@@ -0,0 +1,119 @@
+package i18n
+
+// Plural is a CLDR plural category.
+type Plural string
+
+const (
+	Zero  Plural = "zero"
+	One   Plural = "one"
+	Two   Plural = "two"
+	Few   Plural = "few"
+	Many  Plural = "many"
+	Other Plural = "other"
+)
+
+func isPlural(p Plural) bool {
+	switch p {
+	case Zero, One, Two, Few, Many, Other:
+		return true
+	}
+	return false
+}
+
+func pluralOne(n int) Plural {
+	if n == 1 {
+		return One
+	}
+	return Other
+}
+
+func pluralNone(n int) Plural {
+	return Other
+}
+
+// pluralFrench treats 0 and 1 as singular.
+func pluralFrench(n int) Plural {
+	if n == 0 || n == 1 {
+		return One
+	}
+	return Other
+}
+
+// pluralSlavic is the rule of Russian and Ukrainian.
+func pluralSlavic(n int) Plural {
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return Few
+	}
+	return Many
+}
+
+func pluralPolish(n int) Plural {
+	switch {
+	case n == 1:
+		return One
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return Few
+	}
+	return Many
+}
+
+func pluralCzech(n int) Plural {
+	switch {
+	case n == 1:
+		return One
+	case n >= 2 && n <= 4:
+		return Few
+	}
+	return Other
+}
+
+var pluralRules = map[string]func(n int) Plural{
+	"en": pluralOne,
+	"de": pluralOne,
+	"nl": pluralOne,
+	"sv": pluralOne,
+	"da": pluralOne,
+	"no": pluralOne,
+	"it": pluralOne,
+	"es": pluralOne,
+	"pt": pluralOne,
+	"el": pluralOne,
+	"fr": pluralFrench,
+	"ru": pluralSlavic,
+	"uk": pluralSlavic,
+	"pl": pluralPolish,
+	"cs": pluralCzech,
+	"sk": pluralCzech,
+	"ja": pluralNone,
+	"zh": pluralNone,
+	"ko": pluralNone,
+	"tr": pluralNone,
+}
+
+// PluralRule registers the plural rule of a language (or locale), languages
+// without a rule use the English one.
+func PluralRule(language string, rule func(n int) Plural) {
+	mutex.Lock()
+	pluralRules[normalize(language)] = rule
+	mutex.Unlock()
+}
+
+// pluralOf returns the plural category of n in locale.
+func pluralOf(locale string, n int) Plural {
+	if n < 0 {
+		n = -n
+	}
+	mutex.Lock()
+	rule, ok := pluralRules[locale]
+	if !ok {
+		rule, ok = pluralRules[language(locale)]
+	}
+	mutex.Unlock()
+	if !ok {
+		rule = pluralOne
+	}
+	return rule(n)
+}
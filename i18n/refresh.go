@@ -0,0 +1,22 @@
+// +build !js
+
+package i18n
+
+import (
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Refresh translates the texts rendered below root again, e.g. of views
+// which are not part of the document while the locale is switched.
+func Refresh(root *dom.Element) {
+	for _, element := range root.QueryAll("[" + attribute + "]") {
+		if translate, ok := element.Property("flexkitTranslate").(func()); ok {
+			translate()
+		}
+	}
+}
+
+func refreshDocument() {
+	Refresh(dom.BODY)
+	dom.DOC.SetAttribute("lang", Locale())
+}
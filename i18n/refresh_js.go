@@ -0,0 +1,22 @@
+package i18n
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Refresh translates the texts rendered below root again, e.g. of views
+// which are not part of the document while the locale is switched.
+func Refresh(root *dom.Element) {
+	elements := root.Value.Call("querySelectorAll", "["+attribute+"]")
+	for i := 0; i < elements.Length(); i++ {
+		if translate := elements.Index(i).Get("flexkitTranslate"); translate != js.Undefined {
+			translate.Invoke()
+		}
+	}
+}
+
+func refreshDocument() {
+	Refresh(dom.BODY)
+	dom.DOC.Get("documentElement").Call("setAttribute", "lang", Locale())
+}
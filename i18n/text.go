@@ -0,0 +1,54 @@
+package i18n
+
+import (
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Text is a translatable label, it can be passed to kit widgets in place of
+// a string and is translated again when the locale is switched.
+type Text struct {
+	key   string
+	args  Args
+	count *int
+}
+
+// T creates a translatable label for key.
+func T(key string) *Text {
+	return &Text{
+		key: key,
+	}
+}
+
+// Args sets the values of the placeholders.
+func (t *Text) Args(args Args) *Text {
+	t.args = args
+	return t
+}
+
+// Count selects the plural form for n.
+func (t *Text) Count(n int) *Text {
+	t.count = &n
+	return t
+}
+
+// String translates the text in the current locale.
+func (t *Text) String() string {
+	if t.count != nil {
+		return TranslatePlural(t.key, *t.count, t.args)
+	}
+	return Translate(t.key, t.args)
+}
+
+// attribute marks the elements of rendered texts.
+const attribute = "data-i18n"
+
+// Render the text into a span which is updated when the locale is switched.
+func (t *Text) Render() *dom.Element {
+	span := dom.NewElement("span").SetAttribute(attribute, t.key)
+	update := func() {
+		span.Set("textContent", t.String())
+	}
+	span.Set("flexkitTranslate", update)
+	update()
+	return span
+}
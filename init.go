@@ -0,0 +1,63 @@
+// +build js
+
+package flexkit
+
+import "github.com/satnamram/flexkit/dom"
+
+func init() {
+	dom.HEAD.Append(dom.NewElement("style").Set("innerHTML", CSS).SetAttribute("name", "flexkit.css"))
+}
+
+const CSS = `
+.flex-outlet {
+	height: 100%;
+	width: 100%;
+}
+.flexkit-transition {
+	position: relative;
+	overflow: hidden;
+}
+.flexkit-leave {
+	position: absolute;
+	top: 0;
+	left: 0;
+	width: 100%;
+	height: 100%;
+	pointer-events: none;
+}
+.flexkit-fade-enter, .flexkit-fade-enter-back {animation: flexkit-fade-in 250ms ease both;}
+.flexkit-fade-leave, .flexkit-fade-leave-back {animation: flexkit-fade-out 250ms ease both;}
+.flexkit-slide-enter {animation: flexkit-slide-in-left 250ms ease both;}
+.flexkit-slide-leave {animation: flexkit-slide-out-left 250ms ease both;}
+.flexkit-slide-enter-back {animation: flexkit-slide-in-right 250ms ease both;}
+.flexkit-slide-leave-back {animation: flexkit-slide-out-right 250ms ease both;}
+@keyframes flexkit-fade-in {from {opacity: 0;} to {opacity: 1;}}
+@keyframes flexkit-fade-out {from {opacity: 1;} to {opacity: 0;}}
+@keyframes flexkit-slide-in-left {from {transform: translateX(100%);} to {transform: translateX(0);}}
+@keyframes flexkit-slide-out-left {from {transform: translateX(0);} to {transform: translateX(-100%);}}
+@keyframes flexkit-slide-in-right {from {transform: translateX(-100%);} to {transform: translateX(0);}}
+@keyframes flexkit-slide-out-right {from {transform: translateX(0);} to {transform: translateX(100%);}}
+.flexkit-modal {
+	position: fixed;
+	top: 0;
+	left: 0;
+	width: 100%;
+	height: 100%;
+	z-index: 1010;
+	display: flex;
+	align-items: center;
+	justify-content: center;
+	background: rgba(0, 0, 0, 0.6);
+}
+.flexkit-modal-dialog {
+	max-width: 90%;
+	max-height: 90%;
+	overflow: auto;
+	background: var(--flexkit-surface, #fff);
+	border-radius: var(--flexkit-radius, 0);
+	box-shadow: 0 14px 25px rgba(0, 0, 0, 0.16);
+	outline: none;
+}
+@media (prefers-reduced-motion: reduce) {
+	.flexkit-transition * {animation: none !important;}
+}`
@@ -0,0 +1,123 @@
+// +build js
+
+package flexkit
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// KeepAliveLimit sets how many kept alive views are held in memory, the
+// least recently shown view is dropped first (default: 10).
+func (a *App) KeepAliveLimit(n int) *App {
+	a.mutex.Lock()
+	a.cache.limit = n
+	a.cache.evict()
+	a.mutex.Unlock()
+	return a
+}
+
+// cachedView is the rendered DOM of a kept alive view.
+type cachedView struct {
+	path   string
+	root   *dom.Element
+	scroll []scrollPosition
+}
+
+// scrollPosition is the scroll offset of a scrolled element.
+type scrollPosition struct {
+	element   *js.Object
+	top, left int
+}
+
+// detach records the scroll positions of the page and of the scrolled
+// elements of the view (any overflow container) and removes the view from
+// the document.
+func (c *cachedView) detach(root *dom.Element) {
+	c.scroll = nil
+	record := func(el *js.Object) {
+		top, left := el.Get("scrollTop").Int(), el.Get("scrollLeft").Int()
+		if top != 0 || left != 0 {
+			c.scroll = append(c.scroll, scrollPosition{el, top, left})
+		}
+	}
+	record(root.Value)
+	elements := root.Value.Call("querySelectorAll", "*")
+	for i := 0; i < elements.Length(); i++ {
+		record(elements.Index(i))
+	}
+	// the page scroll is restored even if it was at the top
+	if page := dom.DOC.Get("scrollingElement"); page != nil && page != js.Undefined {
+		c.scroll = append(c.scroll, scrollPosition{page, page.Get("scrollTop").Int(), page.Get("scrollLeft").Int()})
+	}
+	root.Remove()
+}
+
+// attach the view to the mount target (or body) and restore its scroll positions.
+func (c *cachedView) attach(mount *dom.Element) {
+	if mount == nil {
+		mount = dom.BODY
+	}
+	mount.Set("innerHTML", "")
+	mount.Append(c.root)
+	for _, p := range c.scroll {
+		p.element.Set("scrollTop", p.top)
+		p.element.Set("scrollLeft", p.left)
+	}
+}
+
+// viewCache holds kept alive views in least recently used order.
+type viewCache struct {
+	limit int
+	views []*cachedView
+}
+
+func newViewCache(limit int) *viewCache {
+	return &viewCache{
+		limit: limit,
+		views: []*cachedView{},
+	}
+}
+
+// get returns the cached view for path and marks it as recently used.
+func (c *viewCache) get(path string) *cachedView {
+	for i, view := range c.views {
+		if view.path == path {
+			c.views = append(append(c.views[:i:i], c.views[i+1:]...), view)
+			return view
+		}
+	}
+	return nil
+}
+
+// has reports whether a view for path is cached.
+func (c *viewCache) has(path string) bool {
+	for _, view := range c.views {
+		if view.path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// put adds the view as most recently used and drops views above the limit.
+func (c *viewCache) put(view *cachedView) {
+	for i, cached := range c.views {
+		if cached == view {
+			c.views = append(c.views[:i:i], c.views[i+1:]...)
+			break
+		}
+	}
+	c.views = append(c.views, view)
+	c.evict()
+}
+
+func (c *viewCache) evict() {
+	for len(c.views) > 0 && len(c.views) > c.limit {
+		c.views = c.views[1:]
+	}
+}
+
+func (c *viewCache) clear() {
+	c.views = []*cachedView{}
+}
@@ -0,0 +1,51 @@
+package kit
+
+import (
+	"fmt"
+
+	"github.com/satnamram/flexkit/store"
+)
+
+// BindHTML keeps the content of html in sync with v, format converts the
+// value to HTML (fmt.Sprint if nil). Call the returned function to unbind.
+func BindHTML[T any](html *HTML, v store.Observable[T], format func(value T) string) (unbind func()) {
+	if format == nil {
+		format = func(value T) string {
+			return fmt.Sprint(value)
+		}
+	}
+	html.Set(format(v.Get()))
+	return v.Subscribe(func(value T) {
+		html.Set(format(value))
+	})
+}
+
+// BindLabel keeps the label of b in sync with v, label converts the value
+// to a string or renderable (fmt.Sprint if nil).
+func BindLabel[T any](b *Button, v store.Observable[T], label func(value T) interface{}) (unbind func()) {
+	if label == nil {
+		label = func(value T) interface{} {
+			return fmt.Sprint(value)
+		}
+	}
+	b.Label(label(v.Get()))
+	return v.Subscribe(func(value T) {
+		b.Label(label(value))
+	})
+}
+
+// BindRows keeps the rows of t in sync with v, row converts an element of
+// the value to the fields of a table row.
+func BindRows[T any](t *Table, v store.Observable[[]T], row func(value T) []interface{}) (unbind func()) {
+	rows := func(values []T) [][]interface{} {
+		r := make([][]interface{}, 0, len(values))
+		for _, value := range values {
+			r = append(r, row(value))
+		}
+		return r
+	}
+	t.Rows(rows(v.Get()))
+	return v.Subscribe(func(values []T) {
+		t.Rows(rows(values))
+	})
+}
@@ -1,9 +1,8 @@
 package kit
 
 import (
-	"sync"
-	"github.com/gopherjs/gopherjs/js"
 	"github.com/satnamram/flexkit/dom"
+	"sync"
 )
 
 type Form struct {
@@ -11,8 +10,6 @@ type Form struct {
 	ref   *formRef
 
 	margins []*formMargin
-
-	formNode *js.Object
 }
 
 type formRef struct {
@@ -20,7 +17,7 @@ type formRef struct {
 }
 
 type formMargin struct {
-	label   string
+	label      interface{}
 	renderable dom.Renderable
 }
 
@@ -30,11 +27,13 @@ func NewForm() *Form {
 	}
 }
 
-func (f *Form) AddTextbox(label string, t *Textbox) *Form {
+// AddTextbox adds a textbox with a label (a string or renderable, e.g. an
+// i18n.Text), an empty label is omitted.
+func (f *Form) AddTextbox(label interface{}, t *Textbox) *Form {
 	f.mutex.Lock()
 
 	f.margins = append(f.margins, &formMargin{
-		label:   label,
+		label:      label,
 		renderable: t,
 	})
 
@@ -52,8 +51,8 @@ func (f *Form) Render() *dom.Element {
 	for _, margin := range f.margins {
 
 		node := dom.NewElement("div").AddClass("uk-margin")
-		if margin.label != "" {
-			node.Append(dom.NewElement("div").Set("innerHTML", margin.label).AddClass("uk-form-label"))
+		if margin.label != nil && margin.label != "" {
+			node.Append(dom.NewElement("div").SetContent(margin.label).AddClass("uk-form-label"))
 		}
 		node.Append(margin.renderable.Render())
 		f.ref.form.Append(node)
@@ -14,10 +14,28 @@ func init() {
 }
 
 const baseCSS = `html, body {width:100%; height:100%;}
+/* design tokens of the flexkit theme */
+html {
+	font-family: var(--flexkit-font, -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, "Helvetica Neue", Arial, sans-serif);
+	background: var(--flexkit-background, #fff);
+	color: var(--flexkit-text, #666);
+}
+.uk-button, .uk-input, .uk-textarea, .uk-select, .uk-notification-message {border-radius: var(--flexkit-radius, 0);}
+.uk-button-primary {background-color: var(--flexkit-primary, #1e87f0);}
+.uk-button-text, .uk-button-link {color: var(--flexkit-primary, #1e87f0);}
+.uk-input, .uk-textarea, .uk-select, .uk-button-default {border-color: var(--flexkit-border, #e5e5e5);}
+.uk-notification-message {background: var(--flexkit-surface, #f8f8f8);}
 /* we dont want the 20px default because borders look crappy with it */
 .uk-table {margin-bottom: 0px;}
 /* add some background color to the table head */
-.uk-table thead {border-bottom: 3px solid #ababab;}
+.uk-table thead {border-bottom: 3px solid var(--flexkit-border, #ababab);}
+/* notifications always show their close button */
+.kit-notification .uk-notification-close {display: block;}
+.kit-notification-action {margin-top: var(--flexkit-spacing, 10px);}
+/* spacing follows the theme, the multiples keep the UIkit defaults */
+.uk-margin {margin-bottom: calc(2 * var(--flexkit-spacing, 10px));}
+* + .uk-margin {margin-top: calc(2 * var(--flexkit-spacing, 10px)) !important;}
+.uk-table th, .uk-table td {padding: calc(1.6 * var(--flexkit-spacing, 10px)) calc(1.2 * var(--flexkit-spacing, 10px));}
 `
 
 
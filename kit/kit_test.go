@@ -0,0 +1,125 @@
+// +build !js
+
+package kit
+
+import (
+	"testing"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/store"
+)
+
+func TestButton(t *testing.T) {
+	clicks := 0
+	button := NewButton().Label("Save").Style(ButtonPrimary)
+	el := button.Render()
+	button.OnClick(func() {
+		clicks++
+	})
+
+	if el.Tag() != "button" || !el.HasClass("uk-button") || !el.HasClass(string(ButtonPrimary)) {
+		t.Errorf("unexpected button %s", el.OuterHTML())
+	}
+	if el.Text() != "Save" {
+		t.Errorf("got label %q, want Save", el.Text())
+	}
+	el.Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	el.Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if clicks != 2 {
+		t.Errorf("got %d clicks, want 2", clicks)
+	}
+	button.OnClick(nil)
+	el.Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if clicks != 2 {
+		t.Error("removed click listener was called")
+	}
+}
+
+type text string
+
+func (s text) Render() *dom.Element {
+	return dom.NewElement("span").SetContent(string(s))
+}
+
+func TestButtonRelabel(t *testing.T) {
+	button := NewButton().Label(text("Save"))
+	el := button.Render()
+	button.Label(text("Saved"))
+	if spans := el.QueryAll("span"); len(spans) != 1 || el.Text() != "Saved" {
+		t.Errorf("label was not replaced: %s", el.OuterHTML())
+	}
+}
+
+func TestBindLabel(t *testing.T) {
+	price := store.New(1.5)
+	button := NewButton()
+	el := button.Render()
+	unbind := BindLabel(button, price, nil)
+	defer unbind()
+	price.Set(2.25)
+	store.Flush()
+	if el.Text() != "2.25" {
+		t.Errorf("got label %q, want 2.25", el.Text())
+	}
+}
+
+func TestTextboxIcon(t *testing.T) {
+	textbox := NewTextBox()
+	form := dom.NewElement("form").Append(textbox.Render())
+	textbox.Icon(IconUser)
+	textbox.Icon(IconLock)
+	if wrappers := form.QueryAll(".uk-inline"); len(wrappers) != 1 || wrappers[0].Query("input") == nil {
+		t.Errorf("unexpected icon wrapper %s", form.OuterHTML())
+	}
+	textbox.Icon(IconNone)
+	if form.Query(".uk-inline") != nil || form.Query("form > input") == nil {
+		t.Errorf("input was not unwrapped %s", form.OuterHTML())
+	}
+}
+
+func TestTable(t *testing.T) {
+	table := NewTable().Header("Name", "Age").Append("Ada", 36)
+	el := table.Render()
+	table.Append("Alan", 41)
+
+	if headers := el.QueryAll("thead th"); len(headers) != 2 || headers[1].Text() != "Age" {
+		t.Errorf("unexpected header %s", el.Query("thead").OuterHTML())
+	}
+	rows := el.QueryAll("tbody tr")
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if cells := rows[1].QueryAll("td"); cells[0].Text() != "Alan" || cells[1].Text() != "41" {
+		t.Errorf("unexpected row %s", rows[1].OuterHTML())
+	}
+}
+
+func TestNotification(t *testing.T) {
+	defer dom.Reset()
+	closed, actions := 0, 0
+	n := NewNotification("Saved").
+		Level(NotificationSuccess).
+		Action("Undo", func() {
+			actions++
+		}).
+		OnClose(func() {
+			closed++
+		}).
+		Show()
+
+	message := dom.BODY.Query(".uk-notification-top-center .kit-notification")
+	if message == nil || !message.HasClass("uk-notification-message-success") {
+		t.Fatalf("notification not shown: %s", dom.BODY.InnerHTML())
+	}
+	message.Query(".kit-notification-action").Dispatch(dom.NewEvent("click", dom.EventInit{}))
+	if actions != 1 || closed != 1 {
+		t.Errorf("got %d actions and %d closes, want 1 and 1", actions, closed)
+	}
+	if dom.BODY.Query(".kit-notification") != nil {
+		t.Error("closed notification is still shown")
+	}
+	n.Close()
+	if closed != 1 {
+		t.Error("notification closed twice")
+	}
+}
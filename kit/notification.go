@@ -0,0 +1,119 @@
+package kit
+
+import (
+	"sync"
+	"time"
+)
+
+// Notification is a toast message shown by the UIkit notification component.
+type Notification struct {
+	mutex sync.Mutex
+	ref   *notificationRef
+
+	message  interface{}
+	level    NotificationLevel
+	position NotificationPosition
+	timeout  time.Duration
+	action   interface{}
+	onAction func()
+	onClose  func()
+}
+
+type NotificationLevel string
+
+const (
+	NotificationDefault NotificationLevel = ""
+	NotificationPrimary NotificationLevel = "primary"
+	NotificationSuccess NotificationLevel = "success"
+	NotificationWarning NotificationLevel = "warning"
+	NotificationDanger  NotificationLevel = "danger"
+)
+
+// NotificationPosition is the corner (or edge) notifications are stacked in.
+type NotificationPosition string
+
+const (
+	NotificationTopLeft      NotificationPosition = "top-left"
+	NotificationTopCenter    NotificationPosition = "top-center"
+	NotificationTopRight     NotificationPosition = "top-right"
+	NotificationBottomLeft   NotificationPosition = "bottom-left"
+	NotificationBottomCenter NotificationPosition = "bottom-center"
+	NotificationBottomRight  NotificationPosition = "bottom-right"
+)
+
+func NewNotification(message interface{}) *Notification {
+	return &Notification{
+		message:  message,
+		level:    NotificationDefault,
+		position: NotificationTopCenter,
+		timeout:  5 * time.Second,
+	}
+}
+
+func (n *Notification) Level(level NotificationLevel) *Notification {
+	n.mutex.Lock()
+	n.level = level
+	n.mutex.Unlock()
+	return n
+}
+
+func (n *Notification) Position(position NotificationPosition) *Notification {
+	n.mutex.Lock()
+	n.position = position
+	n.mutex.Unlock()
+	return n
+}
+
+// Timeout closes the notification after d, a timeout of 0 keeps it open
+// until it is closed by the user.
+func (n *Notification) Timeout(d time.Duration) *Notification {
+	n.mutex.Lock()
+	n.timeout = d
+	n.mutex.Unlock()
+	return n
+}
+
+// Action adds a button to the notification, clicking it calls f and closes
+// the notification.
+func (n *Notification) Action(label interface{}, f func()) *Notification {
+	n.mutex.Lock()
+	n.cleanupAction()
+	n.action = label
+	n.onAction = f
+	n.renderAction()
+	n.mutex.Unlock()
+	return n
+}
+
+func (n *Notification) cleanupAction() {
+	if n.ref != nil && n.ref.action != nil {
+		n.ref.action.Remove()
+		n.ref.action = nil
+	}
+}
+
+func (n *Notification) renderAction() {
+	if n.ref == nil || n.action == nil {
+		return
+	}
+	onAction := n.onAction
+	n.ref.action = NewButton().
+		Label(n.action).
+		Style(ButtonText).
+		OnClick(func() {
+			if onAction != nil {
+				onAction()
+			}
+		}).
+		Render().
+		AddClass("kit-notification-action")
+	n.ref.content.Append(n.ref.action)
+}
+
+// OnClose is called after the notification was closed.
+func (n *Notification) OnClose(f func()) *Notification {
+	n.mutex.Lock()
+	n.onClose = f
+	n.mutex.Unlock()
+	return n
+}
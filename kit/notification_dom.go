@@ -0,0 +1,70 @@
+// +build !js
+
+package kit
+
+import (
+	"github.com/satnamram/flexkit/dom"
+)
+
+type notificationRef struct {
+	message *dom.Element
+	content *dom.Element
+	action  *dom.Element
+}
+
+// Show the notification, notifications shown at the same position are stacked.
+// Outside of the browser the markup of UIkit is rendered into the body and
+// the notification stays until it is closed, the timeout is ignored.
+func (n *Notification) Show() *Notification {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.ref != nil {
+		return n
+	}
+
+	selector := ".uk-notification.uk-notification-" + string(n.position)
+	container := dom.BODY.Query(selector)
+	if container == nil {
+		container = dom.NewElement("div").
+			AddClass("uk-notification").
+			AddClass("uk-notification-" + string(n.position))
+		dom.BODY.Append(container)
+	}
+	message := dom.NewElement("div").
+		AddClass("uk-notification-message").
+		AddClass("kit-notification")
+	if n.level != NotificationDefault {
+		message.AddClass("uk-notification-message-" + string(n.level))
+	}
+	message.Append(dom.NewElement("a").
+		AddClass("uk-notification-close").
+		SetAttribute("uk-close", ""))
+	message.Append(dom.NewElement("div"))
+
+	n.ref = &notificationRef{
+		message: message,
+		content: dom.NewElement("div").SetContent(n.message),
+	}
+	n.renderAction()
+	message.Children()[1].Append(n.ref.content)
+	container.Append(message)
+	// like UIkit, a click anywhere on the notification closes it
+	message.OnClick(n.Close)
+	return n
+}
+
+// Close the notification if it is shown.
+func (n *Notification) Close() {
+	n.mutex.Lock()
+	ref := n.ref
+	n.ref = nil
+	onClose := n.onClose
+	n.mutex.Unlock()
+	if ref == nil {
+		return
+	}
+	ref.message.Remove()
+	if onClose != nil {
+		onClose()
+	}
+}
@@ -0,0 +1,62 @@
+package kit
+
+import (
+	"time"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+type notificationRef struct {
+	notification *js.Object
+	content      *dom.Element
+	action       *dom.Element
+}
+
+// Show the notification, notifications shown at the same position are stacked.
+func (n *Notification) Show() *Notification {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+	if n.ref != nil {
+		return n
+	}
+
+	notification := js.Global.Get("UIkit").Call("notification", js.M{
+		"message": "",
+		"status":  string(n.level),
+		"pos":     string(n.position),
+		"timeout": int(n.timeout / time.Millisecond),
+	})
+	el := notification.Get("$el")
+	el.Get("classList").Call("add", "kit-notification")
+
+	n.ref = &notificationRef{
+		notification: notification,
+		content:      dom.NewElement("div").SetContent(n.message),
+	}
+	n.renderAction()
+	el.Get("lastElementChild").Call("appendChild", n.ref.content.Value)
+	ref := n.ref
+	dom.Listen(el, "close", func(*dom.Event) {
+		n.mutex.Lock()
+		if n.ref == ref {
+			n.ref = nil
+		}
+		onClose := n.onClose
+		n.mutex.Unlock()
+		if onClose != nil {
+			onClose()
+		}
+	}, dom.ListenerOptions{Once: true})
+	return n
+}
+
+// Close the notification if it is shown.
+func (n *Notification) Close() {
+	n.mutex.Lock()
+	ref := n.ref
+	n.mutex.Unlock()
+	if ref != nil {
+		ref.notification.Call("close", false)
+	}
+}
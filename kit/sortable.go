@@ -0,0 +1,64 @@
+// +build !js
+
+package kit
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/satnamram/flexkit/dom"
+)
+
+// makeSortable sorts the rows by the column of a clicked header outside of
+// the browser, where sorttable.js is not loaded. Like sorttable.js numbers
+// are compared numerically and clicking the same header again reverses the
+// order.
+func makeSortable(table *dom.Element) {
+	column, reversed := -1, false
+	dom.Adopted(func() {
+		table.On("click", func(e *dom.Event) {
+			th := e.Target()
+			for th != nil && th.Tag() != "th" {
+				th = th.Parent()
+			}
+			if th == nil || th.Parent().Parent().Tag() != "thead" {
+				return
+			}
+			index := 0
+			for i, cell := range th.Parent().Children() {
+				if cell == th {
+					index = i
+				}
+			}
+			reversed = index == column && !reversed
+			column = index
+			sortRows(table.Query("tbody"), index, reversed)
+		})
+	})
+}
+
+// sortRows sorts the rows of body by the text of the cells in column.
+func sortRows(body *dom.Element, column int, reversed bool) {
+	rows := body.Children()
+	text := func(row *dom.Element) string {
+		if cells := row.Children(); column < len(cells) {
+			return cells[column].Text()
+		}
+		return ""
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := text(rows[i]), text(rows[j])
+		if reversed {
+			a, b = b, a
+		}
+		x, errX := strconv.ParseFloat(a, 64)
+		y, errY := strconv.ParseFloat(b, 64)
+		if errX == nil && errY == nil {
+			return x < y
+		}
+		return a < b
+	})
+	for _, row := range rows {
+		body.Append(row)
+	}
+}
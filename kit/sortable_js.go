@@ -0,0 +1,15 @@
+package kit
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// makeSortable makes the columns of table sortable using sorttable.js. The
+// listeners of sorttable.js do not move with a hydrated table, it is made
+// sortable once it refers to the adopted node.
+func makeSortable(table *dom.Element) {
+	dom.Adopted(func() {
+		js.Global.Get("sorttable").Call("makeSortable", *table.Value)
+	})
+}
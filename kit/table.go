@@ -1,7 +1,6 @@
 package kit
 
 import (
-	"github.com/gopherjs/gopherjs/js"
 	"sync"
 	"github.com/satnamram/flexkit/dom"
 )
@@ -54,6 +53,15 @@ func (t *Table) Append(v ...interface{}) *Table {
 	return t
 }
 
+// Rows replaces all rows of the table.
+func (t *Table) Rows(rows [][]interface{}) *Table {
+	t.mutex.Lock()
+	t.items = rows
+	t.renderBody()
+	t.mutex.Unlock()
+	return t
+}
+
 func (t *Table) Caption(v interface{}) *Table {
 	t.mutex.Lock()
 	t.caption = v
@@ -170,7 +178,7 @@ func (t *Table) Render() *dom.Element {
 	t.renderBody()
 
 	// make it sortable using sorttable.js
-	js.Global.Get("sorttable").Call("makeSortable", *t.ref.table.Value)
+	makeSortable(t.ref.table)
 
 	t.mutex.Unlock()
 	return t.ref.wrapper
@@ -48,7 +48,7 @@ func NewTextArea() *Textarea {
 
 func (t *Textarea) String() string {
 	if t.ref != nil {
-		return t.ref.textarea.Get("value").String()
+		return t.ref.textarea.GetString("value")
 	}
 	return ""
 }
@@ -63,7 +63,7 @@ func (t *Textarea) Resize(v TextareaResize) *Textarea {
 
 func (t *Textarea) renderResize() {
 	if t.ref != nil {
-		t.ref.textarea.Get("style").Set("resize", string(t.resize))
+		t.ref.textarea.SetStyle("resize", string(t.resize))
 	}
 }
 
@@ -12,6 +12,7 @@ type Textbox struct {
 	state  TextboxState
 	hidden bool
 	icon   IconType
+	onInput func()
 }
 
 type textboxRef struct {
@@ -35,6 +36,24 @@ func NewTextBox() *Textbox {
 	}
 }
 
+// String returns the current value of the textbox.
+func (t *Textbox) String() string {
+	if t.ref != nil {
+		return t.ref.textarea.GetString("value")
+	}
+	return ""
+}
+
+func (t *Textbox) OnInput(f func()) *Textbox {
+	t.mutex.Lock()
+	t.onInput = f
+	if t.ref != nil {
+		t.ref.textarea.OnInput(t.onInput)
+	}
+	t.mutex.Unlock()
+	return t
+}
+
 func (t *Textbox) Hidden(v bool) *Textbox {
 	t.mutex.Lock()
 	t.hidden = v
@@ -129,6 +148,9 @@ func (t *Textbox) Render() *dom.Element {
 	t.renderState()
 	t.renderHidden()
 	t.renderIcon(false)
+	if t.onInput != nil {
+		t.ref.textarea.OnInput(t.onInput)
+	}
 
 	t.mutex.Unlock()
 	if t.ref.wrapper != nil {
@@ -0,0 +1,145 @@
+// +build !js
+
+package kittest
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/store"
+)
+
+// Click dispatches mousedown, mouseup and click to the element, pending
+// store updates are flushed afterwards.
+func (s *Screen) Click(e *dom.Element) {
+	s.t.Helper()
+	s.enabled(e, "click")
+	for _, t := range []string{"mousedown", "mouseup", "click"} {
+		e.Dispatch(dom.NewEvent(t, dom.EventInit{}))
+	}
+	store.Flush()
+}
+
+// Type text into a text input or textarea (or the one inside e, e.g. of a
+// kit.Textbox with an icon): every character is pressed and appended to the
+// value unless the keydown is cancelled, followed by an input event.
+func (s *Screen) Type(e *dom.Element, text string) {
+	s.t.Helper()
+	control := s.control(e)
+	s.enabled(control, "type into")
+	for _, r := range text {
+		key := string(r)
+		init := dom.EventInit{Key: key, Code: code(key), ShiftKey: unicode.IsUpper(r)}
+		if control.Dispatch(dom.NewEvent("keydown", init)) {
+			control.Set("value", control.GetString("value")+key)
+			control.Dispatch(dom.NewEvent("input", dom.EventInit{}))
+		}
+		control.Dispatch(dom.NewEvent("keyup", init))
+	}
+	control.Dispatch(dom.NewEvent("change", dom.EventInit{}))
+	store.Flush()
+}
+
+// Clear the value of a text input or textarea.
+func (s *Screen) Clear(e *dom.Element) {
+	s.t.Helper()
+	control := s.control(e)
+	s.enabled(control, "clear")
+	if control.GetString("value") == "" {
+		return
+	}
+	control.Set("value", "")
+	control.Dispatch(dom.NewEvent("input", dom.EventInit{}))
+	control.Dispatch(dom.NewEvent("change", dom.EventInit{}))
+	store.Flush()
+}
+
+// Press dispatches keydown and keyup of a key combination ("Enter",
+// "ctrl+s", "shift+Tab") to the element, nil dispatches to the document.
+// Press reports whether the keydown was not cancelled.
+func (s *Screen) Press(e *dom.Element, combo string) bool {
+	s.t.Helper()
+	if e == nil {
+		e = dom.DOC
+	}
+	init, ok := parseCombo(combo)
+	if !ok {
+		s.t.Fatalf("kittest: invalid key combination %q", combo)
+	}
+	result := e.Dispatch(dom.NewEvent("keydown", init))
+	e.Dispatch(dom.NewEvent("keyup", init))
+	store.Flush()
+	return result
+}
+
+// control returns e or the text input or textarea inside of it.
+func (s *Screen) control(e *dom.Element) *dom.Element {
+	s.t.Helper()
+	if e.Tag() == "input" || e.Tag() == "textarea" {
+		return e
+	}
+	if control := e.Query("input, textarea"); control != nil {
+		return control
+	}
+	s.t.Fatalf("kittest: no text input in\n%s", e.OuterHTML())
+	return nil
+}
+
+// enabled fails the test if e is disabled.
+func (s *Screen) enabled(e *dom.Element, action string) {
+	s.t.Helper()
+	if disabled := e.GetString("disabled"); disabled == "true" || e.Attribute("disabled") != "" {
+		s.t.Fatalf("kittest: cannot %s disabled element\n%s", action, e.OuterHTML())
+	}
+}
+
+// parseCombo parses a key combination like "ctrl+shift+k" or "ctrl++".
+func parseCombo(combo string) (dom.EventInit, bool) {
+	var init dom.EventInit
+	parts := strings.Split(combo, "+")
+	if strings.HasSuffix(combo, "++") {
+		parts = append(parts[:len(parts)-2], "+")
+	}
+	for _, modifier := range parts[:len(parts)-1] {
+		switch strings.ToLower(modifier) {
+		case "ctrl", "control":
+			init.CtrlKey = true
+		case "alt", "option":
+			init.AltKey = true
+		case "shift":
+			init.ShiftKey = true
+		case "meta", "cmd", "command":
+			init.MetaKey = true
+		default:
+			return init, false
+		}
+	}
+	key := parts[len(parts)-1]
+	if key == "" {
+		return init, false
+	}
+	if utf8.RuneCountInString(key) == 1 && init.ShiftKey {
+		key = strings.ToUpper(key)
+	}
+	init.Key, init.Code = key, code(key)
+	return init, true
+}
+
+// code returns the physical key of a US keyboard layout for key.
+func code(key string) string {
+	if utf8.RuneCountInString(key) != 1 {
+		return key
+	}
+	r, _ := utf8.DecodeRuneInString(key)
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return "Key" + strings.ToUpper(key)
+	case r >= '0' && r <= '9':
+		return "Digit" + key
+	case r == ' ':
+		return "Space"
+	}
+	return ""
+}
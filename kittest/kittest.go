@@ -0,0 +1,250 @@
+// +build !js
+
+// Package kittest renders widgets into the in-memory document of package
+// dom and simulates user interaction in plain Go unit tests:
+//
+//	s := kittest.Render(t, form)
+//	s.Type(s.ByLabel("Name"), "Ada")
+//	s.Click(s.ByRole("button", "Save"))
+//	if s.ByRole("table").Text() != ... {
+//
+// Queries search the whole body, which includes notifications and other
+// overlays rendered next to the widget.
+package kittest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/store"
+)
+
+// Screen is a rendered widget, queries and interactions fail the test if
+// no element matches.
+type Screen struct {
+	t    testing.TB
+	root *dom.Element
+}
+
+// Render r into the empty body of the document, the body is cleared when
+// the test finishes. A panic in an event callback fails the test.
+func Render(t testing.TB, r dom.Renderable) *Screen {
+	t.Helper()
+	dom.Reset()
+	dom.OnPanic(func(value interface{}, stack []byte) {
+		t.Errorf("kittest: panic in event callback: %v\n\n%s", value, stack)
+	})
+	t.Cleanup(func() {
+		dom.OnPanic(nil)
+		dom.Reset()
+	})
+	root := r.Render()
+	dom.BODY.Append(root)
+	store.Flush()
+	return &Screen{t: t, root: root}
+}
+
+// Root returns the element rendered by the widget.
+func (s *Screen) Root() *dom.Element {
+	return s.root
+}
+
+// Text returns the text content of the body.
+func (s *Screen) Text() string {
+	return normalize(dom.BODY.Text())
+}
+
+// HTML returns the markup of the body, e.g. to log it on failures.
+func (s *Screen) HTML() string {
+	return dom.BODY.InnerHTML()
+}
+
+// Query returns the first element matching the CSS selector or nil.
+func (s *Screen) Query(selector string) *dom.Element {
+	return dom.BODY.Query(selector)
+}
+
+// QueryAll returns the elements matching the CSS selector.
+func (s *Screen) QueryAll(selector string) []*dom.Element {
+	return dom.BODY.QueryAll(selector)
+}
+
+// Get returns the first element matching the CSS selector.
+func (s *Screen) Get(selector string) *dom.Element {
+	s.t.Helper()
+	return s.first(s.QueryAll(selector), "selector %q", selector)
+}
+
+// ByText returns the innermost element whose whitespace normalized text is
+// text.
+func (s *Screen) ByText(text string) *dom.Element {
+	s.t.Helper()
+	return s.first(s.AllByText(text), "text %q", text)
+}
+
+// AllByText returns the innermost elements whose whitespace normalized text
+// is text.
+func (s *Screen) AllByText(text string) []*dom.Element {
+	text = normalize(text)
+	return s.filter(func(e *dom.Element) bool {
+		if normalize(e.Text()) != text {
+			return false
+		}
+		for _, child := range e.Children() {
+			if normalize(child.Text()) == text {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// ByLabel returns the form control labelled label, labels are <label>
+// elements (by their for attribute or the control they contain), the
+// labels of kit.Form, aria-label and placeholder attributes.
+func (s *Screen) ByLabel(label string) *dom.Element {
+	s.t.Helper()
+	return s.first(s.AllByLabel(label), "label %q", label)
+}
+
+// AllByLabel returns the form controls labelled label.
+func (s *Screen) AllByLabel(label string) []*dom.Element {
+	label = normalize(label)
+	controls := []*dom.Element{}
+	for _, l := range dom.BODY.QueryAll("label, .uk-form-label") {
+		if normalize(l.Text()) != label {
+			continue
+		}
+		var control *dom.Element
+		switch {
+		case l.Attribute("for") != "":
+			control = dom.BODY.Query("#" + l.Attribute("for"))
+		case l.Query(controlSelector) != nil:
+			control = l.Query(controlSelector)
+		case l.Parent() != nil:
+			// kit.Form renders the label next to the control
+			control = l.Parent().Query(controlSelector)
+		}
+		if control != nil {
+			controls = append(controls, control)
+		}
+	}
+	for _, control := range dom.BODY.QueryAll(controlSelector) {
+		if normalize(control.Attribute("aria-label")) == label ||
+			normalize(control.Attribute("placeholder")) == label {
+			controls = append(controls, control)
+		}
+	}
+	return controls
+}
+
+const controlSelector = "input, textarea, select"
+
+// ByRole returns the first element of the ARIA role (explicit or implied by
+// the tag, e.g. "button", "textbox", "table", "row", "cell", "link"), an
+// optional name has to match the accessible name of the element.
+func (s *Screen) ByRole(role string, name ...string) *dom.Element {
+	s.t.Helper()
+	return s.first(s.AllByRole(role, name...), "role %q %q", role, name)
+}
+
+// AllByRole returns the elements of the ARIA role, see ByRole.
+func (s *Screen) AllByRole(role string, name ...string) []*dom.Element {
+	return s.filter(func(e *dom.Element) bool {
+		if roleOf(e) != role {
+			return false
+		}
+		return len(name) == 0 || accessibleName(e) == normalize(name[0])
+	})
+}
+
+// roleOf returns the explicit or implicit ARIA role of an element.
+func roleOf(e *dom.Element) string {
+	if role := e.Attribute("role"); role != "" {
+		return role
+	}
+	switch e.Tag() {
+	case "button":
+		return "button"
+	case "a":
+		if e.Attribute("href") != "" {
+			return "link"
+		}
+	case "input":
+		switch e.Attribute("type") {
+		case "button", "submit", "reset":
+			return "button"
+		case "checkbox":
+			return "checkbox"
+		case "radio":
+			return "radio"
+		case "", "text", "password", "email", "search", "tel", "url":
+			return "textbox"
+		}
+	case "textarea":
+		return "textbox"
+	case "table":
+		return "table"
+	case "tr":
+		return "row"
+	case "td":
+		return "cell"
+	case "th":
+		return "columnheader"
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return "heading"
+	case "ul", "ol":
+		return "list"
+	case "li":
+		return "listitem"
+	case "img":
+		return "img"
+	case "form":
+		return "form"
+	}
+	return ""
+}
+
+// accessibleName returns the aria-label, the value of button inputs, the
+// placeholder of text inputs or the text of an element.
+func accessibleName(e *dom.Element) string {
+	if label := e.Attribute("aria-label"); label != "" {
+		return normalize(label)
+	}
+	if e.Tag() == "input" && roleOf(e) == "button" {
+		return normalize(e.GetString("value"))
+	}
+	if placeholder := e.Attribute("placeholder"); placeholder != "" {
+		return normalize(placeholder)
+	}
+	if alt := e.Attribute("alt"); alt != "" {
+		return normalize(alt)
+	}
+	return normalize(e.Text())
+}
+
+// filter returns the elements of the body for which f is true.
+func (s *Screen) filter(f func(e *dom.Element) bool) []*dom.Element {
+	elements := []*dom.Element{}
+	for _, e := range dom.BODY.QueryAll("*") {
+		if f(e) {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+// first returns the first element or fails the test.
+func (s *Screen) first(elements []*dom.Element, format string, args ...interface{}) *dom.Element {
+	s.t.Helper()
+	if len(elements) == 0 {
+		s.t.Fatalf("kittest: no element with "+format+" in\n%s", append(args, s.HTML())...)
+	}
+	return elements[0]
+}
+
+// normalize trims the text and collapses whitespace.
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
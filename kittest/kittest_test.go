@@ -0,0 +1,84 @@
+// +build !js
+
+package kittest
+
+import (
+	"testing"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+	"github.com/satnamram/flexkit/kit"
+)
+
+func TestClickUpdatesTable(t *testing.T) {
+	table := kit.NewTable().Header("Name")
+	name := kit.NewTextBox()
+	add := kit.NewButton().Label("Add").OnClick(func() {
+		table.Append(name.String())
+	})
+	form := kit.NewForm().AddTextbox("Name", name)
+	s := Render(t, flex.NewContainer().
+		Append(flex.NewItem(form)).
+		Append(flex.NewItem(add)).
+		Append(flex.NewItem(table)))
+
+	s.Type(s.ByLabel("Name"), "Ada")
+	s.Click(s.ByRole("button", "Add"))
+	s.Clear(s.ByLabel("Name"))
+	s.Type(s.ByLabel("Name"), "Alan")
+	s.Click(s.ByText("Add"))
+
+	cells := s.AllByRole("cell")
+	if len(cells) != 2 || cells[0].Text() != "Ada" || cells[1].Text() != "Alan" {
+		t.Errorf("unexpected table\n%s", s.Get("table").OuterHTML())
+	}
+	if s.ByRole("columnheader").Text() != "Name" {
+		t.Error("header is missing")
+	}
+}
+
+func TestTypeAndPress(t *testing.T) {
+	inputs := 0
+	textarea := kit.NewTextArea().OnInput(func() {
+		inputs++
+	})
+	s := Render(t, textarea)
+
+	el := s.ByRole("textbox")
+	submitted := ""
+	el.On("keydown", func(e *dom.Event) {
+		if e.Key() == "Enter" && e.CtrlKey() {
+			e.PreventDefault()
+			submitted = textarea.String()
+		}
+	})
+	s.Type(el, "Hi!")
+	if inputs != 3 || textarea.String() != "Hi!" {
+		t.Errorf("got %d inputs and %q, want 3 and Hi!", inputs, textarea.String())
+	}
+	if s.Press(el, "ctrl+Enter") || submitted != "Hi!" {
+		t.Errorf("ctrl+Enter was not handled, submitted %q", submitted)
+	}
+	if !s.Press(nil, "Escape") {
+		t.Error("unhandled key was cancelled")
+	}
+}
+
+func TestParseCombo(t *testing.T) {
+	for combo, want := range map[string]dom.EventInit{
+		"Enter":   {Key: "Enter", Code: "Enter"},
+		"ctrl+s":  {Key: "s", Code: "KeyS", CtrlKey: true},
+		"shift+k": {Key: "K", Code: "KeyK", ShiftKey: true},
+		"ctrl++":  {Key: "+", CtrlKey: true},
+		"meta+1":  {Key: "1", Code: "Digit1", MetaKey: true},
+	} {
+		if got, ok := parseCombo(combo); !ok || got != want {
+			t.Errorf("parseCombo(%q) = %+v, %v, want %+v", combo, got, ok, want)
+		}
+	}
+	for _, combo := range []string{"", "ctrl+", "hyper+a"} {
+		if _, ok := parseCombo(combo); ok {
+			t.Errorf("parseCombo(%q) succeeded", combo)
+		}
+	}
+}
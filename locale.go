@@ -0,0 +1,29 @@
+// +build js
+
+package flexkit
+
+import (
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/i18n"
+)
+
+// SetLocale switches the locale of the i18n package, translated texts of the
+// shown view and of kept alive views are updated in place.
+func (a *App) SetLocale(locale string) *App {
+	i18n.SetLocale(locale)
+
+	a.mutex.Lock()
+	roots := []*dom.Element{}
+	for _, cached := range a.cache.views {
+		if cached.root != a.root {
+			roots = append(roots, cached.root)
+		}
+	}
+	a.mutex.Unlock()
+
+	// kept alive views are not part of the document while they are hidden
+	for _, root := range roots {
+		i18n.Refresh(root)
+	}
+	return a
+}
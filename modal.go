@@ -0,0 +1,183 @@
+// +build js
+
+package flexkit
+
+import (
+	"sync"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+)
+
+// focusable elements a modal traps the keyboard focus in.
+const focusable = `a[href], button:not([disabled]), input:not([disabled]), select:not([disabled]), textarea:not([disabled]), [tabindex]:not([tabindex="-1"])`
+
+// Modal is a container shown on top of the current view. Modals are closed
+// by Escape, Back (before the view below is left) or when the view changes.
+type Modal struct {
+	mutex sync.Mutex
+	ref   *modalRef
+	app   *App
+
+	container *flex.Container
+	onClose   func(result interface{})
+	result    chan interface{}
+	closed    bool
+
+	// element which had the focus before the modal was opened
+	focus *js.Object
+}
+
+type modalRef struct {
+	backdrop *dom.Element
+	dialog   *dom.Element
+}
+
+// OpenModal renders the container on top of the current view.
+func (a *App) OpenModal(c *flex.Container) *Modal {
+	m := &Modal{
+		app:       a,
+		container: c,
+		result:    make(chan interface{}, 1),
+		focus:     dom.DOC.Get("activeElement"),
+	}
+	m.ref = &modalRef{
+		backdrop: dom.NewElement("div").AddClass("flexkit-modal"),
+		dialog: dom.NewElement("div").
+			AddClass("flexkit-modal-dialog").
+			SetAttribute("role", "dialog").
+			SetAttribute("aria-modal", "true").
+			SetAttribute("tabindex", "-1"),
+	}
+	m.ref.dialog.Append(c.Render())
+	m.ref.backdrop.Append(m.ref.dialog)
+
+	a.mutex.Lock()
+	a.modals = append(a.modals, m)
+	if len(a.modals) == 1 {
+		a.keydown = dom.Listen(dom.DOC, "keydown", a.onModalKey, dom.ListenerOptions{Capture: true})
+	}
+	a.mutex.Unlock()
+
+	// modals live in the body, rendering a view clears the mount target
+	dom.BODY.Append(m.ref.backdrop)
+	m.focusFirst()
+	return m
+}
+
+// OnClose is called with the result once the modal was closed.
+func (m *Modal) OnClose(f func(result interface{})) *Modal {
+	m.mutex.Lock()
+	m.onClose = f
+	m.mutex.Unlock()
+	return m
+}
+
+// Result receives the result once the modal was closed, the result is nil
+// if the modal was dismissed (Escape, Back or view change).
+func (m *Modal) Result() <-chan interface{} {
+	return m.result
+}
+
+// Close the modal with a result.
+func (m *Modal) Close(result interface{}) {
+	m.mutex.Lock()
+	if m.closed {
+		m.mutex.Unlock()
+		return
+	}
+	m.closed = true
+	onClose := m.onClose
+	m.mutex.Unlock()
+
+	a := m.app
+	a.mutex.Lock()
+	for i, modal := range a.modals {
+		if modal == m {
+			a.modals = append(a.modals[:i:i], a.modals[i+1:]...)
+			break
+		}
+	}
+	if len(a.modals) == 0 {
+		a.keydown.Remove()
+		a.keydown = dom.ListenerHandle{}
+	}
+	a.mutex.Unlock()
+
+	m.ref.backdrop.Remove()
+	if m.focus != nil && m.focus != js.Undefined && m.focus.Get("focus") != js.Undefined {
+		m.focus.Call("focus")
+	}
+	m.result <- result
+	if onClose != nil {
+		onClose(result)
+	}
+}
+
+// CloseModal closes the top most modal with a result and reports whether
+// a modal was open.
+func (a *App) CloseModal(result interface{}) bool {
+	m := a.topModal()
+	if m == nil {
+		return false
+	}
+	m.Close(result)
+	return true
+}
+
+func (a *App) topModal() *Modal {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if len(a.modals) == 0 {
+		return nil
+	}
+	return a.modals[len(a.modals)-1]
+}
+
+// closeModals dismisses all open modals.
+func (a *App) closeModals() {
+	for a.CloseModal(nil) {
+	}
+}
+
+// onModalKey closes the top most modal on Escape and keeps the focus inside
+// of it on Tab.
+func (a *App) onModalKey(event *dom.Event) {
+	m := a.topModal()
+	if m == nil {
+		return
+	}
+	switch event.Key() {
+	case "Escape":
+		event.PreventDefault()
+		m.Close(nil)
+	case "Tab":
+		elements := m.ref.dialog.Value.Call("querySelectorAll", focusable)
+		if elements.Length() == 0 {
+			event.PreventDefault()
+			m.ref.dialog.Value.Call("focus")
+			return
+		}
+		first, last := elements.Index(0), elements.Index(elements.Length()-1)
+		active := dom.DOC.Get("activeElement")
+		inside := m.ref.dialog.Value.Call("contains", active).Bool()
+		if event.ShiftKey() && (active == first || !inside) {
+			event.PreventDefault()
+			last.Call("focus")
+		} else if !event.ShiftKey() && (active == last || !inside) {
+			event.PreventDefault()
+			first.Call("focus")
+		}
+	}
+}
+
+// focusFirst moves the focus to the first focusable element of the modal.
+func (m *Modal) focusFirst() {
+	elements := m.ref.dialog.Value.Call("querySelectorAll", focusable)
+	if elements.Length() == 0 {
+		m.ref.dialog.Value.Call("focus")
+		return
+	}
+	elements.Index(0).Call("focus")
+}
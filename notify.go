@@ -0,0 +1,32 @@
+// +build js
+
+package flexkit
+
+import (
+	"time"
+
+	"github.com/satnamram/flexkit/kit"
+)
+
+// Notify shows a toast message at the notification position of the app, a
+// duration of 0 keeps it open until it is closed. Notify is safe to call
+// from any goroutine, use the returned notification to add an action or to
+// close it early.
+func (a *App) Notify(message interface{}, level kit.NotificationLevel, duration time.Duration) *kit.Notification {
+	a.mutex.Lock()
+	position := a.notificationPosition
+	a.mutex.Unlock()
+	return kit.NewNotification(message).
+		Level(level).
+		Position(position).
+		Timeout(duration).
+		Show()
+}
+
+// NotificationPosition sets where notifications are stacked (default: top center).
+func (a *App) NotificationPosition(position kit.NotificationPosition) *App {
+	a.mutex.Lock()
+	a.notificationPosition = position
+	a.mutex.Unlock()
+	return a
+}
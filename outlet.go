@@ -0,0 +1,266 @@
+// +build js
+
+package flexkit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+)
+
+// Outlet is a region of a view (e.g. the renderable of a flex.Item) which
+// shows one of its own sub views. Register the outlet with View.Outlet and
+// navigating to "settings/profile" only replaces the content of the outlet
+// while the layout of the "settings" view stays mounted.
+type Outlet struct {
+	mutex sync.Mutex
+	ref   *outletRef
+
+	views   []*appView
+	initial string
+	vstack  []string
+	active  *activeView
+
+	// set while the outlet belongs to the active view of a started app
+	app  *App
+	base string
+	ctx  context.Context
+}
+
+type outletRef struct {
+	outlet *dom.Element
+}
+
+func NewOutlet() *Outlet {
+	return &Outlet{
+		views: []*appView{},
+	}
+}
+
+func (o *Outlet) View(name string, container *flex.Container) *Outlet {
+	return o.Add(name, NewView(container))
+}
+
+// Route registers a sub view for a pattern like "orders/:id".
+func (o *Outlet) Route(pattern string, factory func(params Params) *flex.Container) *Outlet {
+	return o.Add(pattern, NewViewFactory(factory))
+}
+
+// Add registers a sub view, errors are logged to the browser console.
+func (o *Outlet) Add(pattern string, v *View) *Outlet {
+	o.mutex.Lock()
+	app := o.app
+	o.mutex.Unlock()
+	app.report(o.Register(pattern, v))
+	return o
+}
+
+// Register registers a sub view and returns ErrViewExists if the pattern
+// is already taken. See App.Register.
+func (o *Outlet) Register(pattern string, v *View) error {
+	o.mutex.Lock()
+	pattern = strings.Trim(pattern, "/")
+	for _, view := range o.views {
+		if view.name == pattern {
+			o.mutex.Unlock()
+			return fmt.Errorf("%w: '%s'", ErrViewExists, pattern)
+		}
+	}
+	o.views = append(o.views, &appView{
+		name:  pattern,
+		route: parseRoute(pattern),
+		view:  v,
+	})
+	app := o.app
+	o.mutex.Unlock()
+	return v.registered(app)
+}
+
+// Default sets the sub view shown if the path does not reference one.
+func (o *Outlet) Default(name string) *Outlet {
+	o.mutex.Lock()
+	o.initial = strings.Trim(name, "/")
+	o.mutex.Unlock()
+	return o
+}
+
+// Goto shows the sub view matching path. If the outlet belongs to the view
+// of a started app the navigation goes through the app, which keeps the URL
+// and the view stack in sync.
+func (o *Outlet) Goto(path string) error {
+	o.mutex.Lock()
+	app, base := o.app, o.base
+	o.mutex.Unlock()
+	if app != nil {
+		return app.GotoPath(base + "/" + strings.Trim(path, "/"))
+	}
+
+	path = strings.Trim(path, "/")
+	if o.match(path) == nil {
+		return fmt.Errorf("%w: '%s'", ErrViewNotFound, path)
+	}
+	if !o.canLeave() {
+		return ErrNavigationCancelled
+	}
+	o.mutex.Lock()
+	o.vstack = append(o.vstack, path)
+	o.mutex.Unlock()
+	o.show(path, Params{})
+	return nil
+}
+
+// Back shows the previous sub view.
+func (o *Outlet) Back() error {
+	o.mutex.Lock()
+	app := o.app
+	if app != nil {
+		o.mutex.Unlock()
+		return app.Back()
+	}
+	if len(o.vstack) <= 1 {
+		o.mutex.Unlock()
+		return nil
+	}
+	o.mutex.Unlock()
+
+	if !o.canLeave() {
+		return ErrNavigationCancelled
+	}
+	o.mutex.Lock()
+	o.vstack = o.vstack[:len(o.vstack)-1]
+	path := o.vstack[len(o.vstack)-1]
+	o.mutex.Unlock()
+	o.show(path, Params{})
+	return nil
+}
+
+// match resolves a sub path, the empty path resolves to the default view.
+func (o *Outlet) match(path string) *target {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	if path == "" {
+		path = o.initial
+	}
+	segments, params := splitPath(path)
+	i := bestRoute(routesOf(o.views), segments)
+	if i < 0 {
+		return nil
+	}
+	o.views[i].route.match(segments, params)
+	return &target{
+		view:   o.views[i].view,
+		params: params,
+		path:   path,
+		base:   path,
+	}
+}
+
+// attach the outlet to the active view of an app.
+func (o *Outlet) attach(a *App, base string, ctx context.Context) {
+	o.mutex.Lock()
+	o.app, o.base, o.ctx = a, base, ctx
+	o.mutex.Unlock()
+}
+
+// show leaves the active sub view and renders the one matching path, the
+// parameters of the parent view are passed on to the sub view.
+func (o *Outlet) show(path string, params Params) {
+	t := o.match(path)
+	if t == nil {
+		return
+	}
+	for key, value := range params {
+		if _, exist := t.params[key]; !exist {
+			t.params[key] = value
+		}
+	}
+
+	o.mutex.Lock()
+	if o.active != nil && o.active.path == t.path {
+		o.mutex.Unlock()
+		return
+	}
+	o.mutex.Unlock()
+	container := t.view.render(t.params)
+
+	o.mutex.Lock()
+	previous := o.active
+	parent := o.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	o.active = &activeView{
+		view:      t.view,
+		base:      t.path,
+		path:      t.path,
+		container: container,
+		cancel:    cancel,
+	}
+	if len(o.vstack) == 0 {
+		o.vstack = []string{t.path}
+	}
+	ref, app, active := o.ref, o.app, o.active
+	o.mutex.Unlock()
+
+	if previous != nil {
+		previous.leave()
+	}
+	if ref != nil {
+		container.RenderInto(ref.outlet)
+	}
+	template := ""
+	if app != nil {
+		app.mutex.Lock()
+		template = app.titleTemplate
+		app.mutex.Unlock()
+	}
+	restore := applyHead(t.view, t.params, template)
+	o.mutex.Lock()
+	active.restore = restore
+	o.mutex.Unlock()
+	if t.view.onEnter != nil {
+		t.view.onEnter(ctx, t.params)
+	}
+}
+
+// leave the active sub view, called when the view of the outlet is left.
+func (o *Outlet) leave() {
+	o.mutex.Lock()
+	active := o.active
+	o.active, o.app, o.ctx = nil, nil, nil
+	o.mutex.Unlock()
+	if active != nil {
+		active.leave()
+	}
+}
+
+// canLeave asks the active sub view whether it may be left.
+func (o *Outlet) canLeave() bool {
+	o.mutex.Lock()
+	active := o.active
+	o.mutex.Unlock()
+	return active == nil || active.view.beforeLeave == nil || active.view.beforeLeave()
+}
+
+func (o *Outlet) Render() *dom.Element {
+	o.mutex.Lock()
+
+	o.ref = &outletRef{
+		outlet: dom.NewElement("div").AddClass("flex-outlet"),
+	}
+	active, standalone := o.active, o.app == nil
+	o.mutex.Unlock()
+
+	// re-render the active sub view, a standalone outlet starts with its default view
+	if active != nil {
+		active.container.RenderInto(o.ref.outlet)
+	} else if standalone {
+		o.show("", Params{})
+	}
+	return o.ref.outlet
+}
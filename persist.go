@@ -0,0 +1,186 @@
+// +build js
+
+package flexkit
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Storage selects where the view stack of an app is persisted.
+type Storage string
+
+const (
+	StorageNone    Storage = ""
+	StorageLocal   Storage = "localStorage"
+	StorageSession Storage = "sessionStorage"
+)
+
+// storedStack is the persisted view stack, State holds the snapshots of
+// the views in the stack by path.
+type storedStack struct {
+	Version int                        `json:"version"`
+	Stack   []string                   `json:"stack"`
+	State   map[string]json.RawMessage `json:"state,omitempty"`
+}
+
+// Persist stores the view stack (and the view snapshots, see View.Snapshot)
+// in storage under key and restores it on Start. The key must be unique
+// among the apps of an origin. Increase the version when views are renamed
+// or removed, a stored stack of another version is discarded.
+func (a *App) Persist(storage Storage, key string, version int) *App {
+	a.mutex.Lock()
+	a.storage, a.storageKey, a.storageVersion = storage, "flexkit:"+key, version
+	a.mutex.Unlock()
+	return a
+}
+
+// Snapshot registers the state of a view which is persisted with the view
+// stack. save returns a JSON encodable value when the view is left, restore
+// gets the JSON of the value when the view is entered again (e.g. after a
+// reload) before OnEnter is called.
+func (v *View) Snapshot(save func() interface{}, restore func(data []byte)) *View {
+	v.saveState = save
+	v.restoreState = restore
+	return v
+}
+
+// call a method of the storage, browsers throw if the storage is disabled.
+func (a *App) callStorage(method string, args ...interface{}) (result *js.Object, err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("flexkit: %s: %v", a.storage, e)
+		}
+	}()
+	storage := js.Global.Get(string(a.storage))
+	if storage == js.Undefined || storage == nil {
+		return nil, fmt.Errorf("flexkit: %s is not available", a.storage)
+	}
+	return storage.Call(method, args...), nil
+}
+
+// loadStack returns the persisted view stack, a stack of another version or
+// with paths which do not match a view any more is removed. It is called
+// with the mutex held, the caller reports the error.
+func (a *App) loadStack() ([]string, error) {
+	if a.storage == StorageNone {
+		return nil, nil
+	}
+	item, err := a.callStorage("getItem", a.storageKey)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, nil
+	}
+	var stored storedStack
+	valid := json.Unmarshal([]byte(item.String()), &stored) == nil &&
+		stored.Version == a.storageVersion &&
+		len(stored.Stack) > 0
+	for _, path := range stored.Stack {
+		if path != "" && a.match(path) == nil {
+			valid = false
+		}
+	}
+	if !valid || stored.Stack[len(stored.Stack)-1] == "" {
+		_, err := a.callStorage("removeItem", a.storageKey)
+		return nil, err
+	}
+	a.snapshots = stored.State
+	return stored.Stack, nil
+}
+
+// known returns the stack without the unknown entries of a reload.
+func known(stack []string) []string {
+	paths := []string{}
+	for _, path := range stack {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// saveStack persists the view stack and the snapshots of its views.
+func (a *App) saveStack() {
+	a.mutex.Lock()
+	if a.storage == StorageNone || a.history == nil {
+		a.mutex.Unlock()
+		return
+	}
+	stored := storedStack{
+		Version: a.storageVersion,
+		Stack:   append([]string{}, a.vstack...),
+		State:   map[string]json.RawMessage{},
+	}
+	for _, path := range a.vstack {
+		if state, ok := a.snapshots[path]; ok {
+			stored.State[path] = state
+		}
+	}
+	a.snapshots = stored.State
+	a.mutex.Unlock()
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		a.report(err)
+		return
+	}
+	_, err = a.callStorage("setItem", a.storageKey, string(data))
+	a.report(err)
+}
+
+// snapshot records the state of the active view.
+func (a *App) snapshot(active *activeView) {
+	if active == nil || active.view.saveState == nil {
+		return
+	}
+	data, err := json.Marshal(active.view.saveState())
+	if err != nil {
+		a.report(fmt.Errorf("flexkit: snapshot of '%s': %w", active.path, err))
+		return
+	}
+	a.mutex.Lock()
+	if a.snapshots == nil {
+		a.snapshots = map[string]json.RawMessage{}
+	}
+	a.snapshots[active.path] = data
+	a.mutex.Unlock()
+}
+
+// restoreSnapshot passes the recorded state to the view of the target.
+func (a *App) restoreSnapshot(t *target) {
+	if t.view.restoreState == nil {
+		return
+	}
+	a.mutex.Lock()
+	data, ok := a.snapshots[t.path]
+	a.mutex.Unlock()
+	if ok {
+		t.view.restoreState(data)
+	}
+}
+
+// listenUnload records the snapshot of the active view before the page is
+// unloaded (or hidden on mobile browsers, which may discard it).
+func (a *App) listenUnload() {
+	if a.storage == StorageNone {
+		return
+	}
+	a.unload = dom.Listen(js.Global, "pagehide", func(*dom.Event) {
+		a.mutex.Lock()
+		active := a.active
+		a.mutex.Unlock()
+		a.snapshot(active)
+		a.saveStack()
+	})
+}
+
+// closeUnload stops listening to the unload of the page.
+func (a *App) closeUnload() {
+	a.unload.Remove()
+	a.unload = dom.ListenerHandle{}
+}
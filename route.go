@@ -0,0 +1,121 @@
+package flexkit
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Params holds the path parameters (":id") and query parameters of a route.
+type Params map[string]string
+
+// Get returns the parameter value or "" if it is not set.
+func (p Params) Get(key string) string {
+	return p[key]
+}
+
+// route is a view pattern like "customers/:id" split into its segments.
+type route []string
+
+func parseRoute(pattern string) route {
+	return route(strings.Split(strings.Trim(pattern, "/"), "/"))
+}
+
+// match the route against the path segments and add the path parameters.
+func (r route) match(segments []string, params Params) bool {
+	if len(segments) != len(r) {
+		return false
+	}
+	values := Params{}
+	for i, segment := range r {
+		if strings.HasPrefix(segment, ":") {
+			value, err := url.PathUnescape(segments[i])
+			if err != nil {
+				return false
+			}
+			values[segment[1:]] = value
+			continue
+		}
+		if segment != segments[i] {
+			return false
+		}
+	}
+	for key, value := range values {
+		params[key] = value
+	}
+	return true
+}
+
+// precedes reports whether r takes precedence over o when both match a
+// path: static segments rank above parameters from left to right, e.g.
+// "customers/new" precedes "customers/:id".
+func (r route) precedes(o route) bool {
+	for i := 0; i < len(r) && i < len(o); i++ {
+		param, otherParam := strings.HasPrefix(r[i], ":"), strings.HasPrefix(o[i], ":")
+		if param != otherParam {
+			return otherParam
+		}
+	}
+	return len(r) > len(o)
+}
+
+// bestRoute returns the index of the route with the highest precedence
+// matching the segments or -1, routes of equal precedence match in the
+// order they were registered.
+func bestRoute(routes []route, segments []string) int {
+	best := -1
+	for i, r := range routes {
+		if !r.match(segments, Params{}) {
+			continue
+		}
+		if best < 0 || r.precedes(routes[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// path builds a path from the route, parameters which are not part of the
+// route are appended as query.
+func (r route) path(params Params) string {
+	used := map[string]bool{}
+	segments := make([]string, len(r))
+	for i, segment := range r {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = url.PathEscape(params[segment[1:]])
+			used[segment[1:]] = true
+			continue
+		}
+		segments[i] = segment
+	}
+
+	keys := []string{}
+	for key := range params {
+		if !used[key] {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return strings.Join(segments, "/")
+	}
+	sort.Strings(keys)
+	query := url.Values{}
+	for _, key := range keys {
+		query.Set(key, params[key])
+	}
+	return strings.Join(segments, "/") + "?" + query.Encode()
+}
+
+// splitPath splits a path like "customers/42?tab=orders" into its segments
+// and query parameters.
+func splitPath(path string) ([]string, Params) {
+	params := Params{}
+	if i := strings.Index(path, "?"); i >= 0 {
+		query, _ := url.ParseQuery(path[i+1:])
+		for key := range query {
+			params[key] = query.Get(key)
+		}
+		path = path[:i]
+	}
+	return strings.Split(strings.Trim(path, "/"), "/"), params
+}
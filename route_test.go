@@ -0,0 +1,37 @@
+package flexkit
+
+import (
+	"testing"
+)
+
+func TestBestRoute(t *testing.T) {
+	for _, patterns := range [][]string{
+		{"customers/:id", "customers/new"},
+		{"customers/new", "customers/:id"},
+	} {
+		routes := []route{parseRoute(patterns[0]), parseRoute(patterns[1])}
+		for path, want := range map[string]string{
+			"customers/new": "customers/new",
+			"customers/42":  "customers/:id",
+			"orders/42":     "",
+		} {
+			got := ""
+			if i := bestRoute(routes, parseRoute(path)); i >= 0 {
+				got = patterns[i]
+			}
+			if got != want {
+				t.Errorf("routes %v: %s matched %q, want %q", patterns, path, got, want)
+			}
+		}
+	}
+}
+
+func TestRoutePrecedence(t *testing.T) {
+	routes := []route{parseRoute(":a/:b"), parseRoute(":a/edit"), parseRoute("users/:b")}
+	if i := bestRoute(routes, parseRoute("users/edit")); i != 2 {
+		t.Errorf("users/edit matched route %d, want 2 (users/:b)", i)
+	}
+	if i := bestRoute(routes, parseRoute("groups/edit")); i != 1 {
+		t.Errorf("groups/edit matched route %d, want 1 (:a/edit)", i)
+	}
+}
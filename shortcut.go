@@ -0,0 +1,356 @@
+// +build js
+
+package flexkit
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+	"github.com/satnamram/flexkit/kit"
+)
+
+// shortcut is a normalized key combination ("ctrl+shift+k") bound to f.
+type shortcut struct {
+	combo       string
+	description string
+	f           func()
+}
+
+// modifiers in the order they appear in a normalized combination.
+var modifiers = []string{"ctrl", "alt", "shift", "meta"}
+
+var keyAliases = map[string]string{
+	"control":  "ctrl",
+	"option":   "alt",
+	"cmd":      "meta",
+	"command":  "meta",
+	"super":    "meta",
+	"win":      "meta",
+	"esc":      "escape",
+	"return":   "enter",
+	"space":    " ",
+	"spacebar": " ",
+	"plus":     "+",
+	"del":      "delete",
+	"up":       "arrowup",
+	"down":     "arrowdown",
+	"left":     "arrowleft",
+	"right":    "arrowright",
+}
+
+func newShortcut(combo string, f func(), description []string) (*shortcut, error) {
+	normalized, err := parseShortcut(combo)
+	if err != nil {
+		return nil, err
+	}
+	return &shortcut{
+		combo:       normalized,
+		description: strings.Join(description, " "),
+		f:           f,
+	}, nil
+}
+
+// Shortcut binds a key combination like "ctrl+s", "mod+k" or "?" to f. The
+// "mod" modifier is Cmd on macOS and Ctrl everywhere else. Combinations
+// without Ctrl, Alt or Meta are ignored while typing into an input. Shortcuts
+// with a description are listed by ShowShortcuts.
+func (a *App) Shortcut(combo string, f func(), description ...string) *App {
+	s, err := newShortcut(combo, f, description)
+	if err != nil {
+		a.report(err)
+		return a
+	}
+	a.mutex.Lock()
+	a.shortcuts = append(a.shortcuts, s)
+	a.mutex.Unlock()
+	return a
+}
+
+// Shortcut binds a key combination to f while the view is shown, shortcuts
+// of the view take precedence over the shortcuts of the app. See App.Shortcut.
+// An invalid combination is returned by App.Register, or reported to the
+// error handler of the app if the view is already registered.
+func (v *View) Shortcut(combo string, f func(), description ...string) *View {
+	s, err := newShortcut(combo, f, description)
+	if err != nil {
+		v.mutex.Lock()
+		app := v.app
+		if app == nil && v.err == nil {
+			v.err = err
+		}
+		v.mutex.Unlock()
+		if app != nil {
+			app.report(err)
+		}
+		return v
+	}
+	v.shortcuts = append(v.shortcuts, s)
+	return v
+}
+
+// registered records the app the view was registered with and returns the
+// error of an invalid shortcut bound before.
+func (v *View) registered(a *App) error {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	if a != nil {
+		v.app = a
+	}
+	err := v.err
+	v.err = nil
+	return err
+}
+
+// ShortcutHelp binds combo (e.g. "?") to ShowShortcuts.
+func (a *App) ShortcutHelp(combo string) *App {
+	return a.Shortcut(combo, func() { a.ShowShortcuts() }, "Show keyboard shortcuts")
+}
+
+// ShowShortcuts opens a modal listing the shortcuts of the shown view and
+// the app which have a description.
+func (a *App) ShowShortcuts() *Modal {
+	rows := ""
+	listed := map[string]bool{}
+	for _, scope := range a.shortcutScopes() {
+		for _, s := range scope {
+			if s.description == "" || listed[s.combo] {
+				continue
+			}
+			listed[s.combo] = true
+			rows += "<tr><td>" + formatShortcut(s.combo) + "</td><td>" + html.EscapeString(s.description) + "</td></tr>"
+		}
+	}
+	return a.OpenModal(flex.NewContainer().
+		Padding("20px").
+		Append(flex.NewItem(kit.NewHTML().Set(
+			`<h3 class="uk-h3">Keyboard shortcuts</h3>` +
+				`<table class="uk-table uk-table-small uk-table-divider"><tbody>` + rows + `</tbody></table>`,
+		))))
+}
+
+// shortcutScopes returns the shortcuts of the shown sub view, the shown view
+// and the app, the most specific scope first.
+func (a *App) shortcutScopes() [][]*shortcut {
+	a.mutex.Lock()
+	active := a.active
+	scopes := [][]*shortcut{a.shortcuts}
+	a.mutex.Unlock()
+	if active == nil {
+		return scopes
+	}
+	scopes = append([][]*shortcut{active.view.shortcuts}, scopes...)
+	if o := active.view.outlet; o != nil {
+		o.mutex.Lock()
+		sub := o.active
+		o.mutex.Unlock()
+		if sub != nil {
+			scopes = append([][]*shortcut{sub.view.shortcuts}, scopes...)
+		}
+	}
+	return scopes
+}
+
+// listenShortcuts starts dispatching keydown events to the shortcuts.
+func (a *App) listenShortcuts() {
+	a.keyListener = dom.Listen(dom.DOC, "keydown", a.onShortcut)
+}
+
+// closeShortcuts stops dispatching keydown events.
+func (a *App) closeShortcuts() {
+	a.keyListener.Remove()
+	a.keyListener = dom.ListenerHandle{}
+}
+
+func (a *App) onShortcut(event *dom.Event) {
+	if event.DefaultPrevented() || a.topModal() != nil {
+		return
+	}
+	candidates := eventShortcuts(event)
+	if len(candidates) == 0 {
+		return
+	}
+	for _, scope := range a.shortcutScopes() {
+		// later registrations override earlier ones
+		for i := len(scope) - 1; i >= 0; i-- {
+			s := scope[i]
+			for _, combo := range candidates {
+				if s.combo != combo {
+					continue
+				}
+				if typing(event) && !modified(s.combo) {
+					return
+				}
+				event.PreventDefault()
+				s.f()
+				return
+			}
+		}
+	}
+}
+
+func isMac() bool {
+	platform := js.Global.Get("navigator").Get("platform")
+	return platform != js.Undefined && strings.Contains(platform.String(), "Mac")
+}
+
+// parseShortcut normalizes a combination like "Mod+Shift+K" to
+// "ctrl+shift+k" ("meta+shift+k" on macOS).
+func parseShortcut(combo string) (string, error) {
+	keys := strings.ToLower(strings.TrimSpace(combo))
+	key := ""
+	if keys == "+" || strings.HasSuffix(keys, "++") {
+		key, keys = "+", strings.TrimSuffix(keys, "+")
+	}
+	pressed := map[string]bool{}
+	for _, part := range strings.Split(keys, "+") {
+		part = strings.TrimSpace(part)
+		if alias, ok := keyAliases[part]; ok {
+			part = alias
+		}
+		if part == "mod" {
+			part = "ctrl"
+			if isMac() {
+				part = "meta"
+			}
+		}
+		switch {
+		case part == "" && key == "+":
+		case isModifier(part):
+			pressed[part] = true
+		case part == "" || key != "":
+			return "", fmt.Errorf("%w: '%s'", ErrInvalidShortcut, combo)
+		default:
+			key = part
+		}
+	}
+	if key == "" {
+		return "", fmt.Errorf("%w: '%s'", ErrInvalidShortcut, combo)
+	}
+	normalized := []string{}
+	for _, m := range modifiers {
+		if pressed[m] {
+			normalized = append(normalized, m)
+		}
+	}
+	return strings.Join(append(normalized, key), "+"), nil
+}
+
+// eventShortcuts returns the normalized combinations a keydown event matches.
+func eventShortcuts(event *dom.Event) []string {
+	key := strings.ToLower(event.Key())
+	if key == "" || isModifier(keyAliases[key]) || isModifier(key) {
+		return nil
+	}
+	// Alt changes the typed character on macOS (Alt+S is "ß"), use the
+	// physical key for letters and digits instead
+	if event.AltKey() {
+		code := event.Code()
+		if strings.HasPrefix(code, "Key") && len(code) == 4 {
+			key = strings.ToLower(code[3:])
+		} else if strings.HasPrefix(code, "Digit") && len(code) == 6 {
+			key = code[5:]
+		}
+	}
+	pressed := map[string]bool{
+		"ctrl":  event.CtrlKey(),
+		"alt":   event.AltKey(),
+		"shift": event.ShiftKey(),
+		"meta":  event.MetaKey(),
+	}
+	combo := []string{}
+	for _, m := range modifiers {
+		if pressed[m] {
+			combo = append(combo, m)
+		}
+	}
+	candidates := []string{strings.Join(append(combo, key), "+")}
+
+	// Shift is implied by characters like "?", which may be bound without it
+	if event.ShiftKey() && len([]rune(key)) == 1 {
+		r := []rune(key)[0]
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) {
+			unshifted := []string{}
+			for _, m := range combo {
+				if m != "shift" {
+					unshifted = append(unshifted, m)
+				}
+			}
+			candidates = append(candidates, strings.Join(append(unshifted, key), "+"))
+		}
+	}
+	return candidates
+}
+
+func isModifier(key string) bool {
+	for _, m := range modifiers {
+		if key == m {
+			return true
+		}
+	}
+	return false
+}
+
+// modified reports whether the combination uses Ctrl, Alt or Meta.
+func modified(combo string) bool {
+	keys := splitShortcut(combo)
+	for _, key := range keys[:len(keys)-1] {
+		if key == "ctrl" || key == "alt" || key == "meta" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitShortcut splits a normalized combination into its keys.
+func splitShortcut(combo string) []string {
+	if combo == "+" {
+		return []string{"+"}
+	}
+	if strings.HasSuffix(combo, "++") {
+		return append(strings.Split(strings.TrimSuffix(combo, "++"), "+"), "+")
+	}
+	return strings.Split(combo, "+")
+}
+
+// typing reports whether the event target is a text input.
+func typing(event *dom.Event) bool {
+	target := event.Target()
+	if target == nil {
+		return false
+	}
+	if target.Get("isContentEditable").Bool() {
+		return true
+	}
+	switch strings.ToLower(target.Get("tagName").String()) {
+	case "input", "textarea", "select":
+		return true
+	}
+	return false
+}
+
+// formatShortcut renders a normalized combination for the help overlay.
+func formatShortcut(combo string) string {
+	mac := isMac()
+	keys := []string{}
+	for _, part := range splitShortcut(combo) {
+		switch {
+		case part == "meta" && mac:
+			part = "Cmd"
+		case part == "alt" && mac:
+			part = "Option"
+		case part == " ":
+			part = "Space"
+		case len([]rune(part)) > 1:
+			part = strings.ToUpper(part[:1]) + part[1:]
+		default:
+			part = strings.ToUpper(part)
+		}
+		keys = append(keys, "<kbd>"+html.EscapeString(part)+"</kbd>")
+	}
+	return strings.Join(keys, " + ")
+}
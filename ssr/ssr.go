@@ -0,0 +1,111 @@
+// +build !js
+
+// Package ssr renders flex and kit trees to static HTML from a regular Go
+// binary, e.g. to send a pre-rendered first paint, generate static pages or
+// snapshot-test layouts.
+//
+// The markup is created with the in-memory document of package dom, event
+// listeners are not part of it. An app started with App.Hydrate adopts the
+// markup, call flex.ResetIDs before building the tree so its IDs match the
+// ones of the app.
+package ssr
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Render returns the markup of r, a panic while rendering is returned as
+// error.
+func Render(r dom.Renderable) (markup string, err error) {
+	defer func() {
+		if value := recover(); value != nil {
+			err = fmt.Errorf("ssr: render panicked: %v", value)
+		}
+	}()
+	return r.Render().OuterHTML(), nil
+}
+
+// Styles returns the style elements the flexkit packages add to the head of
+// the document (UIkit, kit and flex CSS), which the markup of Render
+// depends on.
+func Styles() string {
+	var b strings.Builder
+	for _, style := range dom.HEAD.QueryAll("style") {
+		b.WriteString(style.OuterHTML())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Fragment returns the styles and the markup of r, ready to be inserted
+// into the body of an existing page (see cmd/flexkit).
+func Fragment(r dom.Renderable) (string, error) {
+	markup, err := Render(r)
+	if err != nil {
+		return "", err
+	}
+	return Styles() + markup, nil
+}
+
+// Page is a static HTML document.
+type Page struct {
+	// Title of the document.
+	Title string
+	// Lang is the language of the document ("en", "de-CH").
+	Lang string
+	// Head holds additional markup for the head (meta or link elements).
+	Head string
+	// Scripts are the sources of scripts loaded at the end of the body,
+	// e.g. the client app which replaces the pre-rendered content.
+	Scripts []string
+	// Content is rendered into the body.
+	Content dom.Renderable
+}
+
+// HTML renders the page.
+func (p *Page) HTML() ([]byte, error) {
+	markup, err := Render(p.Content)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n")
+	if p.Lang != "" {
+		b.WriteString(`<html lang="` + html.EscapeString(p.Lang) + `">` + "\n")
+	} else {
+		b.WriteString("<html>\n")
+	}
+	b.WriteString("<head>\n")
+	b.WriteString(`<meta charset="utf-8"/>` + "\n")
+	b.WriteString(`<meta name="viewport" content="width=device-width, initial-scale=1"/>` + "\n")
+	if p.Title != "" {
+		b.WriteString("<title>" + html.EscapeString(p.Title) + "</title>\n")
+	}
+	if p.Head != "" {
+		b.WriteString(p.Head + "\n")
+	}
+	b.WriteString(Styles())
+	b.WriteString("</head>\n<body>\n")
+	b.WriteString(markup + "\n")
+	for _, src := range p.Scripts {
+		b.WriteString(`<script src="` + html.EscapeString(src) + `"></script>` + "\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.Bytes(), nil
+}
+
+// WriteTo renders the page to w.
+func (p *Page) WriteTo(w io.Writer) (int64, error) {
+	data, err := p.HTML()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
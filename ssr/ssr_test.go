@@ -0,0 +1,72 @@
+// +build !js
+
+package ssr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+	"github.com/satnamram/flexkit/kit"
+)
+
+type broken struct{}
+
+func (broken) Render() *dom.Element {
+	panic("broken")
+}
+
+func TestRender(t *testing.T) {
+	c := flex.NewContainer().
+		Append(flex.NewItem(kit.NewButton().Label("Save").Style(kit.ButtonPrimary))).
+		Append(flex.NewItem(kit.NewTable().Header("Name").Append("Ada &amp; Alan")))
+	markup, err := Render(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"<style>" + c.CSS() + "</style>",
+		`<button class="uk-button uk-button-primary">Save</button>`,
+		"<th>Name</th>",
+		"<td>Ada &amp; Alan</td>",
+	} {
+		if !strings.Contains(markup, want) {
+			t.Errorf("markup does not contain %s:\n%s", want, markup)
+		}
+	}
+	if dom.BODY.InnerHTML() != "" {
+		t.Error("rendering changed the document")
+	}
+	if _, err := Render(broken{}); err == nil {
+		t.Error("panic was not returned as error")
+	}
+}
+
+func TestPage(t *testing.T) {
+	page := &Page{
+		Title:   "Tom & Jerry",
+		Lang:    "en",
+		Scripts: []string{"app.js"},
+		Content: kit.NewButton().Label("Start").Style(kit.ButtonPrimary),
+	}
+	var b strings.Builder
+	if _, err := page.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	document := b.String()
+	for _, want := range []string{
+		"<!DOCTYPE html>\n<html lang=\"en\">",
+		"<title>Tom &amp; Jerry</title>",
+		`<style name="flex.css">`,
+		`<button class="uk-button uk-button-primary">Start</button>`,
+		`<script src="app.js"></script>`,
+	} {
+		if !strings.Contains(document, want) {
+			t.Errorf("page does not contain %s", want)
+		}
+	}
+	if strings.Contains(document, `name="uikit.js"`) {
+		t.Error("page contains the scripts of the head")
+	}
+}
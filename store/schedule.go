@@ -0,0 +1,13 @@
+// +build !js
+
+package store
+
+import "time"
+
+// frame is the interval of animation frames outside of the browser.
+const frame = time.Second / 60
+
+// requestFrame calls f after one frame.
+func requestFrame(f func()) {
+	time.AfterFunc(frame, f)
+}
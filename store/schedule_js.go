@@ -0,0 +1,14 @@
+package store
+
+import "github.com/gopherjs/gopherjs/js"
+
+// requestFrame calls f before the next repaint of the browser.
+func requestFrame(f func()) {
+	if raf := js.Global.Get("requestAnimationFrame"); raf != js.Undefined {
+		js.Global.Call("requestAnimationFrame", func() {
+			f()
+		})
+		return
+	}
+	js.Global.Call("setTimeout", f, 16)
+}
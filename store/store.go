@@ -0,0 +1,158 @@
+// Package store implements observable application state. Changes are
+// collected and subscribers are notified once per animation frame, however
+// often a value was set in between.
+package store
+
+import "sync"
+
+// Source is anything whose changes can be observed, computed values use it
+// to depend on values of different types.
+type Source interface {
+	Changed(f func()) (unsubscribe func())
+}
+
+// Observable is a value which notifies its subscribers about changes.
+type Observable[T any] interface {
+	Source
+	Get() T
+	Subscribe(f func(value T)) (unsubscribe func())
+}
+
+// Value is an observable value, the zero value is not usable (see New).
+type Value[T any] struct {
+	mutex       sync.Mutex
+	value       T
+	subscribers []*subscriber[T]
+	dirty       bool
+}
+
+type subscriber[T any] struct {
+	f func(value T)
+}
+
+func New[T any](value T) *Value[T] {
+	return &Value[T]{
+		value:       value,
+		subscribers: []*subscriber[T]{},
+	}
+}
+
+// Computed creates a value which is derived by f from its sources, f is
+// evaluated again whenever one of the sources changed. Call the returned
+// function to unsubscribe from the sources once the value is not needed.
+func Computed[T any](f func() T, sources ...Source) (v *Value[T], unbind func()) {
+	v = New(f())
+	unsubscribes := make([]func(), 0, len(sources))
+	for _, source := range sources {
+		unsubscribes = append(unsubscribes, source.Changed(func() {
+			v.Set(f())
+		}))
+	}
+	return v, func() {
+		for _, unsubscribe := range unsubscribes {
+			unsubscribe()
+		}
+	}
+}
+
+func (v *Value[T]) Get() T {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.value
+}
+
+// Set the value, subscribers are notified with the latest value on the
+// next animation frame.
+func (v *Value[T]) Set(value T) {
+	v.mutex.Lock()
+	v.value = value
+	dirty := v.dirty
+	v.dirty = true
+	v.mutex.Unlock()
+	if !dirty {
+		schedule(v)
+	}
+}
+
+// Update sets the value to the result of f, which gets the current value.
+// f is called without holding the mutex, so it may use the value itself.
+func (v *Value[T]) Update(f func(value T) T) {
+	v.Set(f(v.Get()))
+}
+
+// Subscribe calls f with the value after every change.
+func (v *Value[T]) Subscribe(f func(value T)) (unsubscribe func()) {
+	s := &subscriber[T]{f: f}
+	v.mutex.Lock()
+	v.subscribers = append(v.subscribers, s)
+	v.mutex.Unlock()
+	return func() {
+		v.mutex.Lock()
+		defer v.mutex.Unlock()
+		for i, subscriber := range v.subscribers {
+			if subscriber == s {
+				v.subscribers = append(v.subscribers[:i:i], v.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Changed calls f after every change.
+func (v *Value[T]) Changed(f func()) (unsubscribe func()) {
+	return v.Subscribe(func(T) {
+		f()
+	})
+}
+
+// notify the subscribers, subscribers are called without holding the mutex
+// so they are free to set values.
+func (v *Value[T]) notify() {
+	v.mutex.Lock()
+	v.dirty = false
+	value := v.value
+	subscribers := v.subscribers
+	v.mutex.Unlock()
+	for _, s := range subscribers {
+		s.f(value)
+	}
+}
+
+// notifier is a changed value waiting for the next frame.
+type notifier interface {
+	notify()
+}
+
+var (
+	pending   []notifier
+	pendingMu sync.Mutex
+)
+
+// schedule v to notify its subscribers on the next frame.
+func schedule(v notifier) {
+	pendingMu.Lock()
+	pending = append(pending, v)
+	first := len(pending) == 1
+	pendingMu.Unlock()
+	if first {
+		requestFrame(Flush)
+	}
+}
+
+// Flush notifies the subscribers of all changed values immediately instead
+// of waiting for the next frame. Values changed by subscribers (e.g. computed
+// values) are flushed as well.
+func Flush() {
+	for {
+		pendingMu.Lock()
+		values := pending
+		pending = nil
+		pendingMu.Unlock()
+		if len(values) == 0 {
+			return
+		}
+		for _, v := range values {
+			v.notify()
+		}
+	}
+}
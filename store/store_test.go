@@ -0,0 +1,62 @@
+package store
+
+import "testing"
+
+func TestBatching(t *testing.T) {
+	v := New(0)
+	calls, last := 0, 0
+	v.Subscribe(func(value int) {
+		calls++
+		last = value
+	})
+	v.Set(1)
+	v.Set(2)
+	v.Update(func(value int) int { return value + 1 })
+	Flush()
+	if calls != 1 || last != 3 {
+		t.Fatalf("expected one notification with 3, got %d with %d", calls, last)
+	}
+}
+
+func TestComputed(t *testing.T) {
+	first, last := New("Ada"), New("Lovelace")
+	name, unbind := Computed(func() string {
+		return first.Get() + " " + last.Get()
+	}, first, last)
+	got := ""
+	name.Subscribe(func(value string) { got = value })
+	first.Set("Augusta Ada")
+	Flush()
+	if got != "Augusta Ada Lovelace" {
+		t.Fatalf("unexpected computed value '%s'", got)
+	}
+	unbind()
+	last.Set("King")
+	Flush()
+	if got != "Augusta Ada Lovelace" {
+		t.Fatalf("unbound computed value changed to '%s'", got)
+	}
+}
+
+func TestUpdateUsesValue(t *testing.T) {
+	v := New(1)
+	v.Update(func(value int) int {
+		return value + v.Get()
+	})
+	if got := v.Get(); got != 2 {
+		t.Fatalf("expected 2, got %d", got)
+	}
+	Flush()
+}
+
+func TestUnsubscribe(t *testing.T) {
+	v := New(0)
+	calls := 0
+	unsubscribe := v.Subscribe(func(int) { calls++ })
+	unsubscribe()
+	v.Set(1)
+	Flush()
+	if calls != 0 {
+		t.Fatalf("unsubscribed function was called %d times", calls)
+	}
+}
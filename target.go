@@ -0,0 +1,90 @@
+// +build js
+
+package flexkit
+
+import (
+	"strings"
+
+	"github.com/satnamram/flexkit/flex"
+)
+
+// target is a path resolved to a registered view.
+type target struct {
+	view   *View
+	params Params
+	path   string
+
+	// base is the path of the view itself, sub the remaining path which
+	// is routed into the outlet of the view
+	base string
+	sub  string
+
+	// container is set if the view was already built
+	container *flex.Container
+
+	// pop is set when navigating back
+	pop bool
+}
+
+// key identifies the rendered view, views with an outlet keep their DOM
+// while the outlet switches between sub views.
+func (t *target) key() string {
+	if t.view.outlet != nil {
+		return t.base
+	}
+	return t.path
+}
+
+// match resolves a path to a view.
+func (a *App) match(path string) *target {
+	segments, params := splitPath(path)
+	v, n := matchViews(a.views, segments, params)
+	if v == nil {
+		return nil
+	}
+	return &target{
+		view:   v,
+		params: params,
+		path:   path,
+		base:   strings.Join(segments[:n], "/"),
+		sub:    strings.Join(segments[n:], "/"),
+	}
+}
+
+// matchViews returns the view matching the path segments and how many
+// segments it consumed, see route.precedes for views matching the same
+// path. If no view matches all segments the remaining segments are routed
+// into the outlet of the view matching a prefix.
+func matchViews(views []*appView, segments []string, params Params) (*View, int) {
+	if i := bestRoute(routesOf(views), segments); i >= 0 {
+		views[i].route.match(segments, params)
+		return views[i].view, len(segments)
+	}
+	var prefix *appView
+	for _, view := range views {
+		n := len(view.route)
+		if view.view.outlet == nil || n >= len(segments) {
+			continue
+		}
+		if view.view.outlet.match(strings.Join(segments[n:], "/")) == nil {
+			continue
+		}
+		if view.route.match(segments[:n], Params{}) && (prefix == nil || view.route.precedes(prefix.route)) {
+			prefix = view
+		}
+	}
+	if prefix != nil {
+		n := len(prefix.route)
+		prefix.route.match(segments[:n], params)
+		return prefix.view, n
+	}
+	return nil, 0
+}
+
+func routesOf(views []*appView) []route {
+	routes := make([]route, len(views))
+	for i, view := range views {
+		routes[i] = view.route
+	}
+	return routes
+}
@@ -0,0 +1,159 @@
+// +build js
+
+package flexkit
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Theme holds the design tokens of an app. The tokens are set as CSS custom
+// properties on the document (e.g. "--flexkit-primary") and used by the
+// flex and kit styles, empty tokens keep the default of the stylesheet.
+type Theme struct {
+	// Dark switches UIkit components to their light-on-dark variant.
+	Dark bool
+
+	Primary    string
+	Background string
+	Surface    string
+	Text       string
+	// Border is the color of input, button and table borders.
+	Border string
+	// Radius rounds buttons, inputs, notifications and modal dialogs.
+	Radius string
+	// Spacing is the base unit of the margins between form rows, table
+	// cell paddings and notification actions (default 10px).
+	Spacing string
+	Font    string
+}
+
+var (
+	ThemeLight = Theme{
+		Primary:    "#1e87f0",
+		Background: "#f8f8f8",
+		Surface:    "#fff",
+		Text:       "#666",
+		Border:     "#e5e5e5",
+		Radius:     "0",
+		Spacing:    "10px",
+	}
+	ThemeDark = Theme{
+		Dark:       true,
+		Primary:    "#1e87f0",
+		Background: "#222",
+		Surface:    "#2d2d2d",
+		Text:       "rgba(255, 255, 255, 0.7)",
+		Border:     "rgba(255, 255, 255, 0.2)",
+		Radius:     "0",
+		Spacing:    "10px",
+	}
+)
+
+// tokens maps the CSS custom properties to the tokens of the theme.
+func (t Theme) tokens() map[string]string {
+	return map[string]string{
+		"--flexkit-primary":    t.Primary,
+		"--flexkit-background": t.Background,
+		"--flexkit-surface":    t.Surface,
+		"--flexkit-text":       t.Text,
+		"--flexkit-border":     t.Border,
+		"--flexkit-radius":     t.Radius,
+		"--flexkit-spacing":    t.Spacing,
+		"--flexkit-font":       t.Font,
+	}
+}
+
+// apply the tokens of the theme to the document.
+func (t Theme) apply() {
+	style := dom.DOC.Get("documentElement").Get("style")
+	for property, value := range t.tokens() {
+		if value == "" {
+			style.Call("removeProperty", property)
+		} else {
+			style.Call("setProperty", property, value)
+		}
+	}
+	if t.Dark {
+		dom.BODY.AddClass("uk-light")
+	} else {
+		dom.BODY.RemoveClass("uk-light")
+	}
+}
+
+// SetTheme applies the theme, the theme can be switched at any time.
+func (a *App) SetTheme(t Theme) *App {
+	a.mutex.Lock()
+	a.stopAutoTheme()
+	a.mutex.Unlock()
+	t.apply()
+	return a
+}
+
+// AutoTheme applies the light or dark theme following the color scheme
+// preferred by the user, including changes until the app is stopped.
+// Browsers without matchMedia get the light theme.
+func (a *App) AutoTheme(light, dark Theme) *App {
+	if js.Global.Get("matchMedia") == js.Undefined {
+		a.SetTheme(light)
+		return a
+	}
+	query := js.Global.Call("matchMedia", "(prefers-color-scheme: dark)")
+	apply := func() {
+		if query.Get("matches").Bool() {
+			dark.apply()
+		} else {
+			light.apply()
+		}
+	}
+
+	a.mutex.Lock()
+	a.stopAutoTheme()
+	a.themeQuery = query
+	a.themeListener = js.MakeFunc(func(this *js.Object, arguments []*js.Object) interface{} {
+		apply()
+		return nil
+	})
+	if query.Get("addEventListener") != js.Undefined {
+		query.Call("addEventListener", "change", a.themeListener)
+	} else {
+		query.Call("addListener", a.themeListener)
+	}
+	a.mutex.Unlock()
+
+	apply()
+	return a
+}
+
+// stopAutoTheme stops following the preferred color scheme.
+func (a *App) stopAutoTheme() {
+	if a.themeQuery == nil {
+		return
+	}
+	if a.themeQuery.Get("removeEventListener") != js.Undefined {
+		a.themeQuery.Call("removeEventListener", "change", a.themeListener)
+	} else {
+		a.themeQuery.Call("removeListener", a.themeListener)
+	}
+	a.themeQuery, a.themeListener = nil, nil
+}
+
+// DarkTheme applies ThemeDark with a custom background color ("default" or
+// "" keeps the background of the theme).
+func (a *App) DarkTheme(backgroundColor string) *App {
+	t := ThemeDark
+	if backgroundColor != "default" && backgroundColor != "" {
+		t.Background = backgroundColor
+	}
+	return a.SetTheme(t)
+}
+
+// LightTheme applies ThemeLight with a custom background color ("default"
+// or "" keeps the background of the theme).
+func (a *App) LightTheme(backgroundColor string) *App {
+	t := ThemeLight
+	if backgroundColor != "default" && backgroundColor != "" {
+		t.Background = backgroundColor
+	}
+	return a.SetTheme(t)
+}
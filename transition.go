@@ -0,0 +1,113 @@
+// +build js
+
+package flexkit
+
+import (
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/satnamram/flexkit/dom"
+)
+
+// Transition is the animation played when switching between views.
+type Transition string
+
+const (
+	// TransitionNone replaces the view without animation.
+	TransitionNone Transition = ""
+	// TransitionFade fades the old view out and the new view in.
+	TransitionFade Transition = "fade"
+	// TransitionSlide slides the new view in from the right on Goto and
+	// from the left on Back.
+	TransitionSlide Transition = "slide"
+)
+
+// transitionDuration in milliseconds, has to match the animations in CSS.
+const transitionDuration = 250
+
+// Transition sets the default transition between views. Slide transitions
+// move to the left on Goto and to the right on Back.
+func (a *App) Transition(t Transition) *App {
+	a.mutex.Lock()
+	a.transition = t
+	a.mutex.Unlock()
+	return a
+}
+
+// OnTransitionEnd is called after a transition between views finished.
+func (a *App) OnTransitionEnd(f func()) *App {
+	a.mutex.Lock()
+	a.onTransitionEnd = f
+	a.mutex.Unlock()
+	return a
+}
+
+// Transition overrides the transition of the app when entering the view.
+func (v *View) Transition(t Transition) *View {
+	v.transition = &t
+	return v
+}
+
+// transitionTo returns the transition for entering the view.
+func (a *App) transitionTo(v *View) Transition {
+	if v.transition != nil {
+		return *v.transition
+	}
+	return a.transition
+}
+
+// reducedMotion reports whether the user asked the browser to minimize animations.
+func reducedMotion() bool {
+	matchMedia := js.Global.Get("matchMedia")
+	if matchMedia == js.Undefined {
+		return false
+	}
+	return js.Global.Call("matchMedia", "(prefers-reduced-motion: reduce)").Get("matches").Bool()
+}
+
+// animate the leaving root out of and the entering root into the mount
+// target, the leaving root is removed once the animation finished.
+func (a *App) animate(t Transition, pop bool, mount, leaving, entering *dom.Element) {
+	a.mutex.Lock()
+	a.transitions++
+	id, onEnd := a.transitions, a.onTransitionEnd
+	a.mutex.Unlock()
+
+	if reducedMotion() {
+		if onEnd != nil {
+			onEnd()
+		}
+		return
+	}
+	if mount == nil {
+		mount = dom.BODY
+	}
+	direction := ""
+	if pop {
+		direction = "-back"
+	}
+	leave := "flexkit-" + string(t) + "-leave" + direction
+	enter := "flexkit-" + string(t) + "-enter" + direction
+
+	mount.AddClass("flexkit-transition")
+	leaving.AddClass("flexkit-leave").AddClass(leave)
+	entering.AddClass(enter)
+	mount.Append(leaving)
+
+	js.Global.Call("setTimeout", func() {
+		a.mutex.Lock()
+		last, active := a.transitions == id, a.root == leaving
+		a.mutex.Unlock()
+
+		// the leaving view may have been entered again in the meantime
+		if !active {
+			leaving.Remove()
+		}
+		leaving.RemoveClass("flexkit-leave").RemoveClass(leave)
+		entering.RemoveClass(enter)
+		if last {
+			mount.RemoveClass("flexkit-transition")
+		}
+		if onEnd != nil {
+			onEnd()
+		}
+	}, transitionDuration)
+}
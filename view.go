@@ -0,0 +1,350 @@
+// +build js
+
+package flexkit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/satnamram/flexkit/dom"
+	"github.com/satnamram/flexkit/flex"
+)
+
+// View describes the content of a view and its lifecycle hooks.
+type View struct {
+	mutex     sync.Mutex
+	container *flex.Container
+	factory   func(params Params) *flex.Container
+
+	// lazy views are built on first navigation, building is closed once a
+	// running build finished
+	build       func() *flex.Container
+	placeholder dom.Renderable
+	rebuild     bool
+	building    chan struct{}
+
+	onEnter     func(ctx context.Context, params Params)
+	onLeave     func()
+	beforeLeave func() bool
+
+	keepAlive  bool
+	outlet     *Outlet
+	transition *Transition
+
+	title func(params Params) string
+	meta  []viewMeta
+
+	// shortcuts of the view, err is the first invalid shortcut bound before
+	// the view was registered with app
+	shortcuts []*shortcut
+	err       error
+	app       *App
+
+	saveState    func() interface{}
+	restoreState func(data []byte)
+}
+
+// NewView creates a view showing a pre-built container.
+func NewView(container *flex.Container) *View {
+	return &View{
+		container: container,
+	}
+}
+
+// NewViewFactory creates a view whose container is built from the route
+// parameters on every visit.
+func NewViewFactory(factory func(params Params) *flex.Container) *View {
+	return &View{
+		factory: factory,
+	}
+}
+
+// NewLazyView creates a view whose container is built on the first visit.
+func NewLazyView(build func() *flex.Container) *View {
+	return &View{
+		build: build,
+	}
+}
+
+// Placeholder is shown while a lazy view is being built.
+func (v *View) Placeholder(r dom.Renderable) *View {
+	v.placeholder = r
+	return v
+}
+
+// Rebuild discards the container of a lazy view when it is left and builds
+// it again on every visit.
+func (v *View) Rebuild(rebuild bool) *View {
+	v.rebuild = rebuild
+	return v
+}
+
+// OnEnter is called after the view became visible. The context is cancelled
+// as soon as the view is left, use it to stop background work of the view.
+func (v *View) OnEnter(f func(ctx context.Context, params Params)) *View {
+	v.onEnter = f
+	return v
+}
+
+// OnLeave is called after the view was left.
+func (v *View) OnLeave(f func()) *View {
+	v.onLeave = f
+	return v
+}
+
+// BeforeLeave is called before the view is left, returning false cancels
+// the navigation (e.g. to keep unsaved form data) and App.Goto or App.Back
+// return ErrNavigationCancelled.
+func (v *View) BeforeLeave(f func() bool) *View {
+	v.beforeLeave = f
+	return v
+}
+
+// KeepAlive keeps the rendered view in memory when it is left, entering it
+// again re-attaches the same DOM (including scroll positions and unsaved
+// input) instead of rendering it again. See App.KeepAliveLimit.
+func (v *View) KeepAlive(keep bool) *View {
+	v.keepAlive = keep
+	return v
+}
+
+// Outlet routes the path segments following the view pattern into o, e.g.
+// "settings/profile" shows the "profile" view of the outlet while the view
+// registered as "settings" stays mounted.
+func (v *View) Outlet(o *Outlet) *View {
+	v.outlet = o
+	return v
+}
+
+func (v *View) render(params Params) *flex.Container {
+	if v.factory != nil {
+		return v.factory(params)
+	}
+	v.mutex.Lock()
+	for v.build != nil && v.container == nil {
+		if building := v.building; building != nil {
+			// shown again while the placeholder is building it
+			v.mutex.Unlock()
+			<-building
+			v.mutex.Lock()
+			continue
+		}
+		building := make(chan struct{})
+		v.building = building
+		v.mutex.Unlock()
+		v.buildContainer(building)
+		v.mutex.Lock()
+	}
+	defer v.mutex.Unlock()
+	return v.container
+}
+
+// buildContainer builds the container of a lazy view and closes building,
+// renders of the view started in the meantime wait for it instead of
+// building the view again.
+func (v *View) buildContainer(building chan struct{}) {
+	var c *flex.Container
+	defer func() {
+		v.mutex.Lock()
+		v.container, v.building = c, nil
+		v.mutex.Unlock()
+		close(building)
+	}()
+	c = v.build()
+}
+
+// pending reports whether the lazy view has to be built before it is shown.
+func (v *View) pending() bool {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	return v.build != nil && v.container == nil
+}
+
+// discard drops the container of a lazy view built on every visit.
+func (v *View) discard() {
+	v.mutex.Lock()
+	if v.build != nil && v.rebuild && v.building == nil {
+		v.container = nil
+	}
+	v.mutex.Unlock()
+}
+
+// show renders the view into the mount target, kept alive views are
+// detached from and re-attached to the document instead.
+func (a *App) show(t *target) {
+	a.mutex.Lock()
+	mount, previous, kept := a.mount, a.root, a.kept
+	hydrate := a.hydrate && previous == nil
+	transition := a.transitionTo(t.view)
+	cached := (*cachedView)(nil)
+	if t.view.keepAlive {
+		cached = a.cache.get(t.key())
+	}
+	a.mutex.Unlock()
+
+	// the previous view is kept in the document while the transition plays
+	if kept != nil {
+		kept.detach(previous)
+	} else if previous != nil && transition != TransitionNone {
+		previous.Remove()
+	}
+	var root *dom.Element
+	if cached != nil {
+		cached.attach(mount)
+		root = cached.root
+	} else {
+		c := t.container
+		if c == nil {
+			c = t.view.render(t.params)
+		}
+		switch {
+		case hydrate && mount != nil:
+			root = c.HydrateInto(mount)
+		case hydrate:
+			root = c.HydrateBody()
+		case mount != nil:
+			root = c.RenderInto(mount)
+		default:
+			root = c.RenderToBody()
+		}
+		if t.view.keepAlive {
+			cached = &cachedView{
+				path: t.key(),
+				root: root,
+			}
+		}
+	}
+
+	a.mutex.Lock()
+	a.root = root
+	if cached != nil {
+		a.cache.put(cached)
+	}
+	a.kept = cached
+	a.mutex.Unlock()
+
+	if previous != nil && previous != root && transition != TransitionNone {
+		a.animate(transition, t.pop, mount, previous, root)
+	}
+}
+
+// activeView is the view currently shown by an App (or Outlet).
+type activeView struct {
+	view      *View
+	base      string
+	path      string
+	container *flex.Container
+	cancel    context.CancelFunc
+
+	// restore resets title and meta tags set by the view
+	restore func()
+}
+
+// leave cancels the context of the view and calls its OnLeave hooks.
+func (active *activeView) leave() {
+	if active.view.outlet != nil {
+		active.view.outlet.leave()
+	}
+	active.cancel()
+	if active.restore != nil {
+		active.restore()
+	}
+	if active.view.onLeave != nil {
+		active.view.onLeave()
+	}
+	active.view.discard()
+}
+
+// canLeave asks the active view whether it may be left for t, a view with
+// an outlet which only switches its sub view is not left.
+func (a *App) canLeave(t *target) bool {
+	a.mutex.Lock()
+	active := a.active
+	a.mutex.Unlock()
+	if active == nil {
+		return true
+	}
+	if active.view.outlet != nil && !active.view.outlet.canLeave() {
+		return false
+	}
+	if active.view == t.view && active.base == t.base && t.view.outlet != nil {
+		return true
+	}
+	return active.view.beforeLeave == nil || active.view.beforeLeave()
+}
+
+// enter closes open modals, leaves the active view and shows the target. Hooks are called
+// without holding the app mutex so they are free to navigate.
+func (a *App) enter(t *target) {
+	a.closeModals()
+	defer a.saveStack()
+	a.mutex.Lock()
+	previous := a.active
+	if previous != nil && previous.view == t.view && previous.base == t.base && t.view.outlet != nil {
+		a.mutex.Unlock()
+		t.view.outlet.show(t.sub, t.params)
+		return
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	active := &activeView{
+		view:   t.view,
+		base:   t.base,
+		path:   t.path,
+		cancel: cancel,
+	}
+	a.active = active
+	cached := t.view.keepAlive && a.cache.has(t.key())
+	a.mutex.Unlock()
+
+	if previous != nil {
+		a.snapshot(previous)
+		previous.leave()
+	}
+
+	// show the placeholder of a lazy view and build it in the background,
+	// unless the view was left in the meantime
+	if t.view.placeholder != nil && t.view.pending() && !cached {
+		a.show(&target{
+			view: &View{transition: new(Transition)},
+			container: flex.NewContainer().
+				JustifyContent(flex.Center).
+				AlignItems(flex.Center).
+				Append(flex.NewItem(t.view.placeholder)),
+		})
+		go func() {
+			c := t.view.render(t.params)
+			a.mutex.Lock()
+			left := a.active != active
+			a.mutex.Unlock()
+			if !left {
+				t.container = c
+				a.complete(t, active, ctx)
+			}
+		}()
+		return
+	}
+	a.complete(t, active, ctx)
+}
+
+// complete entering the target by rendering it and calling its hooks.
+func (a *App) complete(t *target, active *activeView, ctx context.Context) {
+	a.mutex.Lock()
+	template := a.titleTemplate
+	a.mutex.Unlock()
+
+	if t.view.outlet != nil {
+		t.view.outlet.attach(a, t.base, ctx)
+	}
+	a.show(t)
+	restore := applyHead(t.view, t.params, template)
+	a.mutex.Lock()
+	active.restore = restore
+	a.mutex.Unlock()
+	a.restoreSnapshot(t)
+	if t.view.outlet != nil {
+		t.view.outlet.show(t.sub, t.params)
+	}
+	if t.view.onEnter != nil {
+		t.view.onEnter(ctx, t.params)
+	}
+}